@@ -0,0 +1,141 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// JournalEntry records the outcome for one finished project, so that an interrupted run can be resumed
+type JournalEntry struct {
+	Time    string `json:"time"`
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Outcome string `json:"outcome"` // succeeded or failed
+	Detail  string `json:"detail,omitempty"`
+}
+
+// Journal appends one line per finished project. When resuming, projects that already succeeded are skipped and failed ones are retried.
+type Journal struct {
+	Filename  string
+	Succeeded int
+	Failed    int
+	Skipped   int
+
+	file     *os.File
+	previous map[string]JournalEntry // last outcome per project ID from the resumed journal
+}
+
+func JournalFilename(prefix string) string {
+	return fmt.Sprintf("%v-journal-%v.jsonl", prefix, time.Now().Format("20060102-150405"))
+}
+
+// OpenJournal reads the previous outcomes if resuming, and opens the file for appending when write is set (ie: -update)
+func OpenJournal(filename string, resume bool, write bool) (*Journal, error) {
+	j := &Journal{
+		Filename: filename,
+		previous: make(map[string]JournalEntry),
+	}
+
+	if resume {
+		entries, err := ReadJournal(filename)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			j.previous[e.ID] = e
+		}
+	}
+
+	if write {
+		file, err := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		j.file = file
+	}
+
+	return j, nil
+}
+
+// Previous returns the number of projects that succeeded and failed according to the resumed journal
+func (j *Journal) Previous() (succeeded, failed int) {
+	for _, e := range j.previous {
+		if e.Outcome == "succeeded" {
+			succeeded++
+		} else {
+			failed++
+		}
+	}
+	return
+}
+
+// Skip is true, and counts the project as skipped, if it already succeeded in the resumed run
+func (j *Journal) Skip(id string) bool {
+	if e, ok := j.previous[id]; ok && e.Outcome == "succeeded" {
+		j.Skipped++
+		return true
+	}
+	return false
+}
+
+// Record counts the outcome, and appends it to the journal file if one is open
+func (j *Journal) Record(id, name string, err error) error {
+	entry := JournalEntry{
+		Time:    time.Now().Format(time.RFC3339),
+		ID:      id,
+		Name:    name,
+		Outcome: "succeeded",
+	}
+	if err != nil {
+		entry.Outcome = "failed"
+		entry.Detail = err.Error()
+		j.Failed++
+	} else {
+		j.Succeeded++
+	}
+
+	if j.file == nil {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = j.file.Write(append(data, '\n'))
+	return err
+}
+
+func (j *Journal) Summary() string {
+	return fmt.Sprintf("%d succeeded, %d failed, %d skipped (already succeeded)", j.Succeeded, j.Failed, j.Skipped)
+}
+
+func (j *Journal) Close() error {
+	if j.file == nil {
+		return nil
+	}
+	return j.file.Close()
+}
+
+func ReadJournal(filename string) ([]JournalEntry, error) {
+	entries := []JournalEntry{}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return entries, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// the last line may be incomplete if the previous run was killed while writing
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,189 @@
+package bulk
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Pacer is an http.RoundTripper that measures the response times and error rates of all Cx1 API calls,
+// and adapts the wait between bulk updates: slowing down on throttling (429), server errors (5xx) or slow responses,
+// and speeding up again while the tenant responds quickly, always within MinDelay and MaxDelay
+type Pacer struct {
+	Adaptive      bool
+	MinDelay      time.Duration
+	MaxDelay      time.Duration
+	TargetLatency time.Duration
+
+	transport  http.RoundTripper
+	logger     *logrus.Logger
+	mutex      sync.Mutex
+	delay      time.Duration
+	retryAfter time.Duration
+	window     PacerStats // since the last Wait
+	total      PacerStats
+	started    time.Time
+	completed  int
+}
+
+type PacerStats struct {
+	Requests  int
+	Throttled int
+	Errors    int
+	Latency   time.Duration
+}
+
+// pacerStep is the smallest change to the delay, so that a delay of 0 can still grow
+const pacerStep = 100 * time.Millisecond
+
+func NewPacer(transport http.RoundTripper, logger *logrus.Logger) *Pacer {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Pacer{
+		Adaptive:  true,
+		transport: transport,
+		logger:    logger,
+		started:   time.Now(),
+	}
+}
+
+// Configure sets the limits once the flags are parsed - the Pacer must already be the client's transport before Cx1ClientGo.NewClient is called
+func (p *Pacer) Configure(delay, minDelay, maxDelay, targetLatency time.Duration, adaptive bool) error {
+	if minDelay > maxDelay {
+		return fmt.Errorf("minimum delay %v is larger than maximum delay %v", minDelay, maxDelay)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.MinDelay = minDelay
+	p.MaxDelay = maxDelay
+	p.TargetLatency = targetLatency
+	p.Adaptive = adaptive
+	p.delay = p.clamp(delay)
+	p.started = time.Now()
+	return nil
+}
+
+func (p *Pacer) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := p.transport.RoundTrip(req)
+	latency := time.Since(start)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, stats := range []*PacerStats{&p.window, &p.total} {
+		stats.Requests++
+		stats.Latency += latency
+		if err != nil || resp.StatusCode >= 500 {
+			stats.Errors++
+		} else if resp.StatusCode == http.StatusTooManyRequests {
+			stats.Throttled++
+		}
+	}
+
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		if seconds, perr := strconv.Atoi(resp.Header.Get("Retry-After")); perr == nil {
+			p.retryAfter = max(p.retryAfter, time.Duration(seconds)*time.Second)
+		}
+	}
+
+	return resp, err
+}
+
+func (p *Pacer) Delay() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.delay
+}
+
+// SetDelay overrides the current delay within MinDelay and MaxDelay, and returns the delay applied. Adaptive pacing continues from the new value
+func (p *Pacer) SetDelay(delay time.Duration) time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.delay = p.clamp(delay)
+	return p.delay
+}
+
+// Done counts one finished entity, for the throughput report
+func (p *Pacer) Done() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.completed++
+}
+
+// Wait adjusts the delay based on the API calls made since the previous Wait, then sleeps for delay * weight.
+// The weight scales the wait for updates that cause more work on the server, eg: one per project in an application
+func (p *Pacer) Wait(weight int) {
+	p.mutex.Lock()
+	if p.Adaptive {
+		p.adjust()
+	}
+	p.window = PacerStats{}
+	wait := p.delay * time.Duration(max(weight, 1))
+	p.mutex.Unlock()
+
+	time.Sleep(wait)
+}
+
+func (p *Pacer) adjust() {
+	old := p.delay
+	w := p.window
+	if w.Requests == 0 {
+		return
+	}
+	avg := w.Latency / time.Duration(w.Requests)
+
+	switch {
+	case w.Throttled > 0:
+		p.delay = max(2*p.delay+pacerStep, p.retryAfter)
+		p.retryAfter = 0
+	case w.Errors > 0:
+		p.delay = p.delay*3/2 + pacerStep
+	case p.TargetLatency > 0 && avg > p.TargetLatency:
+		p.delay = p.delay*5/4 + pacerStep
+	case p.TargetLatency == 0 || avg < p.TargetLatency/2:
+		p.delay = p.delay * 9 / 10
+	}
+	p.delay = p.clamp(p.delay)
+
+	if p.delay > old {
+		p.logger.Warnf("Slowing down: %d requests, %d throttled, %d errors, average response time %v - delay increased from %v to %v", w.Requests, w.Throttled, w.Errors, avg.Round(time.Millisecond), old, p.delay)
+	} else if p.delay < old {
+		p.logger.Debugf("Speeding up: %d requests, average response time %v - delay decreased from %v to %v", w.Requests, avg.Round(time.Millisecond), old, p.delay)
+	}
+}
+
+func (p *Pacer) clamp(delay time.Duration) time.Duration {
+	delay = delay.Round(time.Millisecond)
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	if delay < p.MinDelay {
+		return p.MinDelay
+	}
+	return delay
+}
+
+// Report summarizes the throughput achieved and the API health over the whole run
+func (p *Pacer) Report() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	elapsed := time.Since(p.started)
+	perMinute := 0.0
+	if elapsed > 0 {
+		perMinute = float64(p.completed) / elapsed.Minutes()
+	}
+	avg := time.Duration(0)
+	if p.total.Requests > 0 {
+		avg = p.total.Latency / time.Duration(p.total.Requests)
+	}
+
+	return fmt.Sprintf("%d processed in %v (%.1f per minute), %d API calls with average response time %v, %d throttled, %d errors, final delay %v",
+		p.completed, elapsed.Round(time.Second), perMinute, p.total.Requests, avg.Round(time.Millisecond), p.total.Throttled, p.total.Errors, p.delay)
+}
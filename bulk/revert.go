@@ -0,0 +1,193 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RevertEntry records a project's full tag map before a change, and the tags that the change wrote
+type RevertEntry struct {
+	Time      string            `json:"time"`
+	ProjectID string            `json:"projectId"`
+	Name      string            `json:"name"`
+	Before    map[string]string `json:"before"`
+	After     map[string]string `json:"after"`
+}
+
+type RevertLog struct {
+	Filename string
+	file     *os.File
+}
+
+func RevertFilename(prefix string) string {
+	return fmt.Sprintf("%v-revert-%v.jsonl", prefix, time.Now().Format("20060102-150405"))
+}
+
+func OpenRevertLog(filename string) (*RevertLog, error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &RevertLog{Filename: filename, file: file}, nil
+}
+
+// Record must be called before the project is updated, so that the previous tags are kept even if the run is interrupted
+func (r *RevertLog) Record(projectID, name string, before, after map[string]string) error {
+	data, err := json.Marshal(RevertEntry{
+		Time:      time.Now().Format(time.RFC3339),
+		ProjectID: projectID,
+		Name:      name,
+		Before:    before,
+		After:     after,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.file.Write(append(data, '\n'))
+	return err
+}
+
+func (r *RevertLog) Close() error {
+	return r.file.Close()
+}
+
+// ReadRevertLog returns the entries in the file, and the numbers of the lines that could not be read.
+// A line is incomplete if a run was killed while writing it, and since the file is appended to it is not always the last line.
+func ReadRevertLog(filename string) ([]RevertEntry, []int, error) {
+	entries := []RevertEntry{}
+	invalid := []int{}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return entries, invalid, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var entry RevertEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			invalid = append(invalid, line)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, invalid, scanner.Err()
+}
+
+// ProjectTagger reads and writes project tags for RevertTags, so that each tool can implement it with its own version of Cx1ClientGo
+type ProjectTagger interface {
+	// GetProjectTags returns the project as shown in the output (eg: project.String()) and its current tags
+	GetProjectTags(projectID string) (string, map[string]string, error)
+	SetProjectTags(projectID string, tags map[string]string) error
+}
+
+// RevertTags puts back the project tags recorded in the revert file - all tags, or only the given keys.
+// Projects whose tags no longer match what the change wrote were changed by someone else since then, and are skipped.
+func RevertTags(tagger ProjectTagger, filename string, keys []string, update bool, logger *logrus.Logger) {
+	entries, invalid, err := ReadRevertLog(filename)
+	if err != nil {
+		logger.Fatalf("Failed to read revert file %v: %s", filename, err)
+	}
+	for _, line := range invalid {
+		logger.Warnf("Skipping line %d of %v - it is incomplete or not a revert entry", line, filename)
+	}
+
+	// a project changed several times is reverted to the tags before its first change, if it still has the tags written by one of the changes.
+	// Entries are recorded before the update, so the last ones may not have been applied if the update failed.
+	order := []string{}
+	first := make(map[string]RevertEntry)
+	afters := make(map[string][]map[string]string)
+	for _, e := range entries {
+		if _, ok := first[e.ProjectID]; !ok {
+			first[e.ProjectID] = e
+			order = append(order, e.ProjectID)
+		}
+		afters[e.ProjectID] = append(afters[e.ProjectID], e.After)
+	}
+
+	logger.Infof("Read %d changes to %d projects from %v", len(entries), len(order), filename)
+	if len(keys) > 0 {
+		logger.Infof("Only the tag keys %v will be reverted", keys)
+	}
+
+	reverted, unchanged, skipped, failed := 0, 0, 0, 0
+	for i, id := range order {
+		progress := fmt.Sprintf("[#%d/%d] ", i+1, len(order))
+		before := first[id].Before
+
+		project, current, err := tagger.GetProjectTags(id)
+		if err != nil {
+			logger.Errorf("%vFailed to get project %v (%v): %s", progress, id, first[id].Name, err)
+			failed++
+			continue
+		}
+
+		if current == nil {
+			current = make(map[string]string)
+		}
+
+		restored := maps.Clone(before)
+		if len(keys) > 0 {
+			restored = maps.Clone(current)
+			for _, key := range keys {
+				if value, ok := before[key]; ok {
+					restored[key] = value
+				} else {
+					delete(restored, key)
+				}
+			}
+		}
+		if restored == nil {
+			restored = make(map[string]string)
+		}
+
+		if maps.Equal(current, restored) {
+			logger.Infof("%vProject %v already has the previous tags", progress, project)
+			unchanged++
+			continue
+		}
+
+		if !slices.ContainsFunc(afters[id], func(after map[string]string) bool { return TagsEqual(current, after, keys) }) {
+			after := afters[id][len(afters[id])-1]
+			logger.Warnf("%vSkipping project %v - its tags were changed since: expected %v, found %v", progress, project, after, current)
+			skipped++
+			continue
+		}
+
+		for _, change := range TagChanges(current, restored) {
+			logger.Infof("%v%v: %v", progress, project, change)
+		}
+
+		if !update {
+			reverted++
+			continue
+		}
+
+		if err = tagger.SetProjectTags(id, restored); err != nil {
+			logger.Errorf("%vFailed to revert tags on project %v: %s", progress, project, err)
+			failed++
+		} else {
+			logger.Infof("%vReverted tags on project %v", progress, project)
+			reverted++
+		}
+	}
+
+	if update {
+		logger.Infof("Revert done - %d projects reverted, %d already had the previous tags, %d skipped as changed since, %d failed", reverted, unchanged, skipped, failed)
+	} else {
+		logger.Infof("Revert would change %d projects, %d already have the previous tags, %d skipped as changed since, %d failed", reverted, unchanged, skipped, failed)
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/cxpsemea/cx1_go_scripts/bulk/selection
+
+go 1.23.3
+
+require github.com/cxpsemea/Cx1ClientGo v0.1.18
+
+require (
+	github.com/golang-jwt/jwt/v4 v4.5.2 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	golang.org/x/exp v0.0.0-20250408133849-7e4ce0ab07d0 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+)
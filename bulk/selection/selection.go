@@ -0,0 +1,251 @@
+package selection
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// Selection picks projects or applications with filters instead of a file of IDs. All filters that are set must match.
+type Selection struct {
+	Name          string
+	Tags          string
+	Application   string
+	Group         string
+	CreatedAfter  string
+	CreatedBefore string
+	ScannedWithin int
+	NotScannedFor int
+	Branch        string
+	List          bool
+
+	nameRE   *regexp.Regexp
+	branchRE *regexp.Regexp
+	tags     map[string]*string // key -> value, nil to only require the key
+	after    time.Time
+	before   time.Time
+}
+
+// AddFlags registers the filter flags - the application, group, scan and branch filters only apply to projects
+func AddFlags(projects bool) *Selection {
+	s := &Selection{}
+	flag.StringVar(&s.Name, "name", "", "Select by name, regular expression")
+	flag.StringVar(&s.Tags, "with-tags", "", "Select by tags, comma-separated list of key or key=value, all must be present")
+	flag.StringVar(&s.CreatedAfter, "created-after", "", "Select those created on or after this date (YYYY-MM-DD)")
+	flag.StringVar(&s.CreatedBefore, "created-before", "", "Select those created before this date (YYYY-MM-DD)")
+	if projects {
+		flag.StringVar(&s.Application, "in-app", "", "Select projects belonging to this application (name)")
+		flag.StringVar(&s.Group, "in-group", "", "Select projects assigned to this group (path, eg: /AppSec/Payments)")
+		flag.IntVar(&s.ScannedWithin, "scanned-within", 0, "Select projects with a scan in the last N days")
+		flag.IntVar(&s.NotScannedFor, "not-scanned-for", 0, "Select projects without a scan in the last N days, including projects never scanned")
+		flag.StringVar(&s.Branch, "primary-branch", "", "Select projects by primary branch, regular expression - use ^$ for projects without a primary branch")
+	}
+	flag.BoolVar(&s.List, "list", false, "Only list what the filters select (all, if no filter is set), without checking or changing anything")
+	return s
+}
+
+// Active is true when any filter is set, in which case the selection replaces the file of IDs
+func (s *Selection) Active() bool {
+	return s.Name != "" || s.Tags != "" || s.Application != "" || s.Group != "" || s.CreatedAfter != "" || s.CreatedBefore != "" ||
+		s.ScannedWithin > 0 || s.NotScannedFor > 0 || s.Branch != ""
+}
+
+func (s *Selection) String() string {
+	filters := []string{}
+	add := func(name, value string) {
+		if value != "" {
+			filters = append(filters, fmt.Sprintf("%v '%v'", name, value))
+		}
+	}
+	add("name matches", s.Name)
+	add("tags", s.Tags)
+	add("in application", s.Application)
+	add("in group", s.Group)
+	add("created after", s.CreatedAfter)
+	add("created before", s.CreatedBefore)
+	if s.ScannedWithin > 0 {
+		filters = append(filters, fmt.Sprintf("scanned within %d days", s.ScannedWithin))
+	}
+	if s.NotScannedFor > 0 {
+		filters = append(filters, fmt.Sprintf("not scanned for %d days", s.NotScannedFor))
+	}
+	add("primary branch matches", s.Branch)
+
+	if len(filters) == 0 {
+		return "all"
+	}
+	return strings.Join(filters, ", ")
+}
+
+func (s *Selection) compile() error {
+	var err error
+	if s.Name != "" {
+		if s.nameRE, err = regexp.Compile(s.Name); err != nil {
+			return fmt.Errorf("invalid name regex: %s", err)
+		}
+	}
+	if s.Branch != "" {
+		if s.branchRE, err = regexp.Compile(s.Branch); err != nil {
+			return fmt.Errorf("invalid primary branch regex: %s", err)
+		}
+	}
+
+	s.tags = make(map[string]*string)
+	for _, t := range strings.Split(s.Tags, ",") {
+		if strings.TrimSpace(t) == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(t, "=")
+		if hasValue {
+			s.tags[strings.TrimSpace(key)] = &value
+		} else {
+			s.tags[strings.TrimSpace(key)] = nil
+		}
+	}
+
+	if s.CreatedAfter != "" {
+		if s.after, err = time.Parse("2006-01-02", s.CreatedAfter); err != nil {
+			return fmt.Errorf("invalid created-after date: %s", err)
+		}
+	}
+	if s.CreatedBefore != "" {
+		if s.before, err = time.Parse("2006-01-02", s.CreatedBefore); err != nil {
+			return fmt.Errorf("invalid created-before date: %s", err)
+		}
+	}
+	return nil
+}
+
+// matchCommon checks the filters shared by projects and applications
+func (s *Selection) matchCommon(name string, tags map[string]string, createdAt string) bool {
+	if s.nameRE != nil && !s.nameRE.MatchString(name) {
+		return false
+	}
+
+	for key, value := range s.tags {
+		if v, ok := tags[key]; !ok || (value != nil && v != *value) {
+			return false
+		}
+	}
+
+	if !s.after.IsZero() || !s.before.IsZero() {
+		created, err := parseTime(createdAt)
+		if err != nil {
+			return false
+		}
+		if !s.after.IsZero() && created.Before(s.after) {
+			return false
+		}
+		if !s.before.IsZero() && !created.Before(s.before) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Selection) SelectProjects(cx1client *Cx1ClientGo.Cx1Client) ([]Cx1ClientGo.Project, error) {
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+
+	appID, groupID := "", ""
+	if s.Application != "" {
+		app, err := cx1client.GetApplicationByName(s.Application)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application %v: %s", s.Application, err)
+		}
+		appID = app.ApplicationID
+	}
+	if s.Group != "" {
+		group, err := cx1client.GetGroupByPath(s.Group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group %v: %s", s.Group, err)
+		}
+		groupID = group.GroupID
+	}
+
+	projects, err := cx1client.GetAllProjects()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %s", err)
+	}
+
+	selected := []Cx1ClientGo.Project{}
+	for _, p := range projects {
+		if !s.matchCommon(p.Name, p.Tags, p.CreatedAt) {
+			continue
+		}
+		if appID != "" && !slices.Contains(p.Applications, appID) {
+			continue
+		}
+		if groupID != "" && !slices.Contains(p.Groups, groupID) {
+			continue
+		}
+		if s.branchRE != nil && !s.branchRE.MatchString(p.MainBranch) {
+			continue
+		}
+
+		// checked last, since it costs an API call per project
+		if s.ScannedWithin > 0 || s.NotScannedFor > 0 {
+			lastScan, err := lastScanDate(cx1client, p.ProjectID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get last scan of project %v: %s", p.String(), err)
+			}
+			if s.ScannedWithin > 0 && (lastScan.IsZero() || time.Since(lastScan) > time.Duration(s.ScannedWithin)*24*time.Hour) {
+				continue
+			}
+			if s.NotScannedFor > 0 && !lastScan.IsZero() && time.Since(lastScan) <= time.Duration(s.NotScannedFor)*24*time.Hour {
+				continue
+			}
+		}
+
+		selected = append(selected, p)
+	}
+
+	return selected, nil
+}
+
+func (s *Selection) SelectApplications(cx1client *Cx1ClientGo.Cx1Client) ([]Cx1ClientGo.Application, error) {
+	if s.Application != "" || s.Group != "" || s.ScannedWithin > 0 || s.NotScannedFor > 0 || s.Branch != "" {
+		return nil, fmt.Errorf("the application, group, scan and primary branch filters only apply to projects")
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+
+	apps, err := cx1client.GetAllApplications()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications: %s", err)
+	}
+
+	selected := []Cx1ClientGo.Application{}
+	for _, a := range apps {
+		if s.matchCommon(a.Name, a.Tags, a.CreatedAt) {
+			selected = append(selected, a)
+		}
+	}
+
+	return selected, nil
+}
+
+// lastScanDate returns the creation time of the most recent scan, or a zero time for projects without scans
+func lastScanDate(cx1client *Cx1ClientGo.Cx1Client, projectID string) (time.Time, error) {
+	_, scans, err := cx1client.GetScansFiltered(Cx1ClientGo.ScanFilter{
+		ProjectID:  projectID,
+		Sort:       []string{"-created_at"},
+		BaseFilter: Cx1ClientGo.BaseFilter{Limit: 1},
+	})
+	if err != nil || len(scans) == 0 {
+		return time.Time{}, err
+	}
+	return parseTime(scans[0].CreatedAt)
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
@@ -0,0 +1,51 @@
+package bulk
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+)
+
+// TagsEqual compares two tag maps, only on the given keys if any
+func TagsEqual(a, b map[string]string, keys []string) bool {
+	if len(keys) == 0 {
+		return maps.Equal(a, b)
+	}
+	for _, key := range keys {
+		va, oka := a[key]
+		vb, okb := b[key]
+		if oka != okb || va != vb {
+			return false
+		}
+	}
+	return true
+}
+
+// TagChanges describes the difference between two tag maps, one line per key, quoted so that differences in whitespace show
+func TagChanges(from, to map[string]string) []string {
+	keys := []string{}
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := []string{}
+	for _, key := range keys {
+		old, hadOld := from[key]
+		value, hasNew := to[key]
+		switch {
+		case !hasNew:
+			changes = append(changes, fmt.Sprintf("- tag '%v' = '%v'", key, old))
+		case !hadOld:
+			changes = append(changes, fmt.Sprintf("+ tag '%v' = '%v'", key, value))
+		case old != value:
+			changes = append(changes, fmt.Sprintf("~ tag '%v': '%v' -> '%v'", key, old, value))
+		}
+	}
+	return changes
+}
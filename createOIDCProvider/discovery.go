@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// OpenIDConfiguration holds the fields of the issuer's .well-known/openid-configuration document that Keycloak needs
+type OpenIDConfiguration struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserInfoEndpoint      string   `json:"userinfo_endpoint"`
+	JwksURI               string   `json:"jwks_uri"`
+	EndSessionEndpoint    string   `json:"end_session_endpoint"`
+	ScopesSupported       []string `json:"scopes_supported"`
+}
+
+// ReadDiscovery loads the openid-configuration document from a URL or a local file.
+// An issuer URL without the .well-known suffix is also accepted.
+func ReadDiscovery(client *http.Client, location string) (OpenIDConfiguration, error) {
+	var config OpenIDConfiguration
+	var data []byte
+	var err error
+
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		if !strings.Contains(location, "/.well-known/") {
+			location = strings.TrimSuffix(location, "/") + "/.well-known/openid-configuration"
+		}
+
+		response, err := client.Get(location)
+		if err != nil {
+			return config, err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return config, fmt.Errorf("HTTP %v from %v", response.Status, location)
+		}
+		if data, err = io.ReadAll(response.Body); err != nil {
+			return config, err
+		}
+	} else if data, err = os.ReadFile(location); err != nil {
+		return config, err
+	}
+
+	if err = json.Unmarshal(data, &config); err != nil {
+		return config, err
+	}
+
+	if config.Issuer == "" || config.AuthorizationEndpoint == "" || config.TokenEndpoint == "" {
+		return config, fmt.Errorf("document from %v is missing the issuer, authorization_endpoint or token_endpoint", location)
+	}
+	return config, nil
+}
+
+// ProviderConfig returns the Keycloak OIDC identity provider config for this issuer
+func (o OpenIDConfiguration) ProviderConfig(clientId, clientSecret, scopes string) map[string]string {
+	config := map[string]string{
+		"issuer":            o.Issuer,
+		"authorizationUrl":  o.AuthorizationEndpoint,
+		"tokenUrl":          o.TokenEndpoint,
+		"clientId":          clientId,
+		"clientAuthMethod":  "client_secret_post",
+		"defaultScope":      scopes,
+		"syncMode":          "FORCE",
+		"validateSignature": "true",
+		"useJwksUrl":        "true",
+		"pkceEnabled":       "false",
+	}
+	if clientSecret != "" {
+		config["clientSecret"] = clientSecret
+	}
+	if o.UserInfoEndpoint != "" {
+		config["userInfoUrl"] = o.UserInfoEndpoint
+	}
+	if o.JwksURI != "" {
+		config["jwksUrl"] = o.JwksURI
+	}
+	if o.EndSessionEndpoint != "" {
+		config["logoutUrl"] = o.EndSessionEndpoint
+	}
+	return config
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cxpsemea/cx1_go_scripts/iam"
+)
+
+// The Cx1ClientGo AuthenticationProvider type does not carry the provider config (endpoints, client credentials),
+// so providers are read and created with the IAM admin API directly.
+type IdentityProvider struct {
+	Alias       string            `json:"alias"`
+	DisplayName string            `json:"displayName,omitempty"`
+	ProviderID  string            `json:"providerId"`
+	Enabled     bool              `json:"enabled"`
+	TrustEmail  bool              `json:"trustEmail"`
+	Config      map[string]string `json:"config"`
+}
+
+// IAMClient adds the identity provider requests to the shared IAM client
+type IAMClient struct {
+	*iam.Client
+}
+
+func NewIAMClient(client *http.Client) (*IAMClient, error) {
+	c, err := iam.NewClient(client)
+	if err != nil {
+		return nil, err
+	}
+	return &IAMClient{c}, nil
+}
+
+func (c IAMClient) GetProviders() ([]IdentityProvider, error) {
+	var idps []IdentityProvider
+	data, err := c.SendRequest(http.MethodGet, "/identity-provider/instances", nil)
+	if err != nil {
+		return idps, err
+	}
+
+	err = json.Unmarshal(data, &idps)
+	return idps, err
+}
+
+// FindProvider returns the provider with the given alias, and false if there is none
+func (c IAMClient) FindProvider(alias string) (IdentityProvider, bool, error) {
+	idps, err := c.GetProviders()
+	if err != nil {
+		return IdentityProvider{}, false, err
+	}
+	for _, idp := range idps {
+		if idp.Alias == alias {
+			return idp, true, nil
+		}
+	}
+	return IdentityProvider{}, false, nil
+}
+
+func (c IAMClient) CreateProvider(idp IdentityProvider) error {
+	jsonBody, _ := json.Marshal(idp)
+	_, err := c.SendRequest(http.MethodPost, "/identity-provider/instances", bytes.NewReader(jsonBody))
+	return err
+}
+
+// UpdateProviderConfig merges the given config keys into the provider, leaving all other settings as they are
+func (c IAMClient) UpdateProviderConfig(alias string, config map[string]string) error {
+	api := fmt.Sprintf("/identity-provider/instances/%v", url.PathEscape(alias))
+	data, err := c.SendRequest(http.MethodGet, api, nil)
+	if err != nil {
+		return err
+	}
+
+	var idp map[string]interface{}
+	if err = json.Unmarshal(data, &idp); err != nil {
+		return err
+	}
+
+	current, ok := idp["config"].(map[string]interface{})
+	if !ok {
+		current = make(map[string]interface{})
+	}
+	for key, value := range config {
+		current[key] = value
+	}
+	idp["config"] = current
+
+	jsonBody, _ := json.Marshal(idp)
+	_, err = c.SendRequest(http.MethodPut, api, bytes.NewReader(jsonBody))
+	return err
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/iam"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+func main() {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	logger.Info("Starting")
+
+	providerName := flag.String("provider-alias", "", "Alias of the OIDC IdP in CheckmarxOne - it will be created if it does not exist yet")
+	displayName := flag.String("display-name", "", "Optional: name shown on the login page for a new IdP")
+	discovery := flag.String("discovery", "", "URL or local file of the issuer's .well-known/openid-configuration document - required to create a new IdP")
+	idpClientID := flag.String("idp-client-id", "", "Client ID registered for CheckmarxOne at the OIDC issuer - required to create a new IdP")
+	idpClientSecret := flag.String("idp-client-secret", "", "Client secret registered for CheckmarxOne at the OIDC issuer - only set on an existing IdP together with -rotate-secret")
+	rotateSecret := flag.Bool("rotate-secret", false, "Replace the client secret of an existing IdP with -idp-client-secret")
+	scopes := flag.String("scopes", "openid email profile", "Scopes requested from the OIDC issuer")
+	usernameClaim := flag.String("username-claim", "preferred_username", "Claim containing the username")
+	emailClaim := flag.String("email-claim", "email", "Claim containing the email address")
+	firstNameClaim := flag.String("firstname-claim", "given_name", "Claim containing the first name")
+	lastNameClaim := flag.String("lastname-claim", "family_name", "Claim containing the last name")
+	groupsClaim := flag.String("groups-claim", "groups", "Claim containing the group values, used with -mapping")
+	mappingFile := flag.String("mapping", "", "Optional: CSV (claim,group,role) or YAML file mapping groups claim values to Cx1 group paths and roles")
+	update := flag.Bool("update", false, "Create or update the IdP and its mappers, otherwise only inform")
+
+	httpClient := &http.Client{}
+
+	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
+	if err != nil {
+		logger.Fatalf("Error creating client: %s", err)
+	}
+
+	logger.Infof("Connected with %v", cx1client.String())
+
+	if *providerName == "" {
+		logger.Fatalf("The provider-alias parameter must be set")
+	}
+
+	iamclient, err := NewIAMClient(httpClient)
+	if err != nil {
+		logger.Fatalf("Error creating IAM client: %s", err)
+	}
+
+	if *update {
+		logger.Warn("The 'update' flag is set - the IdP and missing mappers will be created or updated")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	var config map[string]string
+	if *discovery != "" {
+		oidcConfig, err := ReadDiscovery(httpClient, *discovery)
+		if err != nil {
+			logger.Fatalf("Failed to read openid-configuration from %v: %s", *discovery, err)
+		}
+		logger.Infof("Read openid-configuration for issuer %v", oidcConfig.Issuer)
+		config = oidcConfig.ProviderConfig(*idpClientID, *idpClientSecret, *scopes)
+	}
+
+	if *rotateSecret && *idpClientSecret == "" {
+		logger.Fatalf("The rotate-secret parameter requires idp-client-secret")
+	}
+
+	idp, providerExists, err := iamclient.FindProvider(*providerName)
+	if err != nil {
+		logger.Fatalf("Unable to get idps: %s", err)
+	}
+
+	if providerExists {
+		if idp.ProviderID != "oidc" {
+			logger.Fatalf("IdP %v already exists but is of type %v, not oidc", idp.Alias, idp.ProviderID)
+		}
+		logger.Infof("Found IDP: %v (%v)", idp.Alias, idp.ProviderID)
+
+		changes := make(map[string]string)
+		for key, value := range config {
+			if (key == "clientId" || key == "clientSecret") && value == "" {
+				continue
+			}
+			if key != "clientSecret" && idp.Config[key] != value {
+				logger.Infof(" - config %v differs: '%v' -> '%v'", key, idp.Config[key], value)
+				changes[key] = value
+			}
+		}
+
+		// the secret cannot be read back to compare, so it is only replaced on request
+		if *rotateSecret {
+			logger.Infof(" - config clientSecret will be rotated")
+			changes["clientSecret"] = *idpClientSecret
+		} else if *idpClientSecret != "" {
+			logger.Infof(" - the idp-client-secret parameter is ignored for the existing IdP %v, use -rotate-secret to replace the secret", idp.Alias)
+		}
+
+		if len(changes) == 0 {
+			logger.Infof("IdP %v configuration is up to date", idp.Alias)
+		} else if !*update {
+			logger.Infof("Would update %d config settings on IdP %v", len(changes), idp.Alias)
+		} else if err = iamclient.UpdateProviderConfig(idp.Alias, changes); err != nil {
+			logger.Fatalf("Failed to update IdP %v: %s", idp.Alias, err)
+		} else {
+			logger.Infof("Updated %d config settings on IdP %v", len(changes), idp.Alias)
+		}
+	} else {
+		if config == nil || *idpClientID == "" {
+			logger.Fatalf("IdP %v does not exist - the discovery and idp-client-id parameters are required to create it", *providerName)
+		}
+
+		idp = IdentityProvider{
+			Alias:       *providerName,
+			DisplayName: *displayName,
+			ProviderID:  "oidc",
+			Enabled:     true,
+			Config:      config,
+		}
+
+		if !*update {
+			logger.Infof("Would create OIDC IdP %v for issuer %v", idp.Alias, config["issuer"])
+		} else if err = iamclient.CreateProvider(idp); err != nil {
+			logger.Fatalf("Failed to create IdP %v: %s", idp.Alias, err)
+		} else {
+			logger.Infof("Created OIDC IdP %v for issuer %v", idp.Alias, config["issuer"])
+			providerExists = true
+		}
+	}
+
+	existing := []iam.IdPMapper{}
+	if providerExists {
+		if existing, err = iamclient.GetMappers(*providerName); err != nil {
+			logger.Fatalf("Failed to get existing mappers for idp %v: %s", *providerName, err)
+		}
+	}
+
+	mappers := MakeAttributeMappers(*providerName, *usernameClaim, *emailClaim, *firstNameClaim, *lastNameClaim)
+	if *mappingFile != "" {
+		mappings, err := iam.ReadMappingFile(*mappingFile)
+		if err != nil {
+			logger.Fatalf("Failed to read mapping file %v: %s", *mappingFile, err)
+		}
+		logger.Infof("Read %d claim mappings from %v", len(mappings), *mappingFile)
+		mappers = append(mappers, MakeClaimMappers(cx1client, *providerName, *groupsClaim, mappings, logger)...)
+	}
+
+	created := 0
+	for _, mapper := range mappers {
+		if mapperExists(existing, mapper) {
+			logger.Infof("Mapper already exists: %v", mapper.String())
+			continue
+		}
+
+		if !*update {
+			logger.Infof("Would create mapper: %v", mapper.String())
+			continue
+		}
+
+		if err := iamclient.AddMapper(mapper); err != nil {
+			logger.Errorf("Failed to create mapper %v: %s", mapper.String(), err)
+		} else {
+			logger.Infof("Created mapper: %v", mapper.String())
+			created++
+		}
+	}
+
+	logger.Infof("Done - created %d mappers", created)
+}
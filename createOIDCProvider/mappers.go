@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/iam"
+	"github.com/sirupsen/logrus"
+)
+
+// MakeAttributeMappers returns the mappers for the username, email and name claims
+func MakeAttributeMappers(alias, usernameClaim, emailClaim, firstNameClaim, lastNameClaim string) []iam.IdPMapper {
+	attribute := func(name, claim, userAttribute string) iam.IdPMapper {
+		return iam.IdPMapper{
+			Name:   name,
+			Alias:  alias,
+			Mapper: "oidc-user-attribute-idp-mapper",
+			Config: map[string]string{
+				"syncMode":       "FORCE",
+				"claim":          claim,
+				"user.attribute": userAttribute,
+			},
+		}
+	}
+
+	return []iam.IdPMapper{
+		{
+			Name:   "Username",
+			Alias:  alias,
+			Mapper: "oidc-username-idp-mapper",
+			Config: map[string]string{
+				"syncMode": "FORCE",
+				"template": fmt.Sprintf("${CLAIM.%v}", usernameClaim),
+				"target":   "LOCAL",
+			},
+		},
+		attribute("Email", emailClaim, "email"),
+		attribute("First Name", firstNameClaim, "firstName"),
+		attribute("Last Name", lastNameClaim, "lastName"),
+	}
+}
+
+// MakeClaimMappers validates the target groups and roles in Cx1 and returns the advanced claim mappers for each mapping
+func MakeClaimMappers(cx1client *Cx1ClientGo.Cx1Client, alias, groupsClaim string, mappings []iam.ClaimMapping, logger *logrus.Logger) []iam.IdPMapper {
+	mappers := []iam.IdPMapper{}
+
+	for _, m := range mappings {
+		claims, _ := json.Marshal([]map[string]string{{"key": groupsClaim, "value": m.Claim}})
+
+		if m.Group != "" {
+			path := m.Group
+			if !strings.HasPrefix(path, "/") {
+				path = "/" + path
+			}
+
+			if _, err := cx1client.GetGroupByPath(path); err != nil {
+				logger.Errorf("Mapping %v: group %v does not exist in Cx1 and must be created first: %s", m.String(), path, err)
+			} else {
+				mappers = append(mappers, iam.IdPMapper{
+					Name:   fmt.Sprintf("%v to group %v", m.Claim, path),
+					Alias:  alias,
+					Mapper: "oidc-advanced-group-idp-mapper",
+					Config: map[string]string{
+						"syncMode":               "FORCE",
+						"claims":                 string(claims),
+						"are.claim.values.regex": "false",
+						"group":                  path,
+					},
+				})
+			}
+		}
+
+		if m.Role != "" {
+			roleName := strings.TrimPrefix(m.Role, "ast-app.")
+			if role, err := cx1client.GetRoleByName(roleName); err != nil {
+				logger.Errorf("Mapping %v: role %v does not exist in Cx1: %s", m.String(), roleName, err)
+			} else {
+				roleRef := role.Name
+				if role.ClientRole {
+					roleRef = "ast-app." + role.Name
+				}
+				mappers = append(mappers, iam.IdPMapper{
+					Name:   fmt.Sprintf("%v to role %v", m.Claim, role.Name),
+					Alias:  alias,
+					Mapper: "oidc-advanced-role-idp-mapper",
+					Config: map[string]string{
+						"syncMode":               "FORCE",
+						"claims":                 string(claims),
+						"are.claim.values.regex": "false",
+						"role":                   roleRef,
+					},
+				})
+			}
+		}
+	}
+
+	return mappers
+}
+
+// mapperExists checks for an existing mapper of the same type with the same config, regardless of its name and sync mode
+func mapperExists(existing []iam.IdPMapper, mapper iam.IdPMapper) bool {
+	for _, e := range existing {
+		if e.Mapper != mapper.Mapper {
+			continue
+		}
+
+		match := true
+		for key, value := range mapper.Config {
+			if key != "syncMode" && e.Config[key] != value {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/iam"
 	"github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
@@ -22,6 +23,9 @@ func main() {
 	logger.Info("Starting")
 
 	providerName := flag.String("provider-alias", "", "Alias (display name) of the SAML IdP which already exists in CheckmarxOne")
+	mappingFile := flag.String("mapping", "", "Optional: CSV (claim,group,role) or YAML file mapping IdP group claim values to Cx1 group paths and roles - the default mappers are created otherwise")
+	claimAttribute := flag.String("attribute", "Groups", "SAML attribute containing the group claim values, used with -mapping")
+	update := flag.Bool("update", false, "Create the mappers from the -mapping file, otherwise only inform")
 
 	httpClient := &http.Client{}
 
@@ -43,6 +47,11 @@ func main() {
 
 	logger.Infof("Found IDP: %v", idp.String())
 
+	if *mappingFile != "" {
+		createClaimMappers(cx1client, httpClient, idp, *mappingFile, *claimAttribute, *update, logger)
+		return
+	}
+
 	mapper, _ := idp.MakeDefaultMapper("firstname")
 	_ = cx1client.AddAuthenticationProviderMapper(mapper)
 	mapper, _ = idp.MakeDefaultMapper("lastname")
@@ -57,3 +66,49 @@ func main() {
 	_ = cx1client.AddAuthenticationProviderMapper(mapper)
 
 }
+
+func createClaimMappers(cx1client *Cx1ClientGo.Cx1Client, httpClient *http.Client, idp Cx1ClientGo.AuthenticationProvider, mappingFile, attribute string, update bool, logger *logrus.Logger) {
+	mappings, err := iam.ReadMappingFile(mappingFile)
+	if err != nil {
+		logger.Fatalf("Failed to read mapping file %v: %s", mappingFile, err)
+	}
+	logger.Infof("Read %d claim mappings from %v", len(mappings), mappingFile)
+
+	iamclient, err := iam.NewClient(httpClient)
+	if err != nil {
+		logger.Fatalf("Error creating IAM client: %s", err)
+	}
+
+	existing, err := iamclient.GetMappers(idp.Alias)
+	if err != nil {
+		logger.Fatalf("Failed to get existing mappers for idp %v: %s", idp.String(), err)
+	}
+
+	if update {
+		logger.Warn("The 'update' flag is set - missing mappers will be created")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	created := 0
+	for _, mapper := range MakeClaimMappers(cx1client, idp, attribute, mappings, logger) {
+		if mapperExists(existing, mapper) {
+			logger.Infof("Mapper already exists: %v", mapper.String())
+			continue
+		}
+
+		if !update {
+			logger.Infof("Would create mapper: %v", mapper.String())
+			continue
+		}
+
+		if err := iamclient.AddMapper(mapper); err != nil {
+			logger.Errorf("Failed to create mapper %v: %s", mapper.String(), err)
+		} else {
+			logger.Infof("Created mapper: %v", mapper.String())
+			created++
+		}
+	}
+
+	logger.Infof("Done - created %d mappers", created)
+}
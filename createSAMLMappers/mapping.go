@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/iam"
+	"github.com/sirupsen/logrus"
+)
+
+// MakeClaimMappers validates the target groups and roles in Cx1 and returns the advanced attribute mappers for each mapping
+func MakeClaimMappers(cx1client *Cx1ClientGo.Cx1Client, idp Cx1ClientGo.AuthenticationProvider, attribute string, mappings []iam.ClaimMapping, logger *logrus.Logger) []iam.IdPMapper {
+	mappers := []iam.IdPMapper{}
+
+	for _, m := range mappings {
+		attributes, _ := json.Marshal([]map[string]string{{"key": attribute, "value": m.Claim}})
+
+		if m.Group != "" {
+			path := m.Group
+			if !strings.HasPrefix(path, "/") {
+				path = "/" + path
+			}
+
+			if group, err := cx1client.GetGroupByPath(path); err != nil {
+				logger.Errorf("Mapping %v: group %v does not exist in Cx1 and must be created first: %s", m.String(), path, err)
+			} else {
+				logger.Debugf("Mapping %v: found group %v", m.String(), group.String())
+				mappers = append(mappers, iam.IdPMapper{
+					Name:   fmt.Sprintf("%v to group %v", m.Claim, path),
+					Alias:  idp.Alias,
+					Mapper: "saml-advanced-group-idp-mapper",
+					Config: map[string]string{
+						"syncMode":                   "FORCE",
+						"attributes":                 string(attributes),
+						"are.attribute.values.regex": "false",
+						"group":                      path,
+					},
+				})
+			}
+		}
+
+		if m.Role != "" {
+			roleName := strings.TrimPrefix(m.Role, "ast-app.")
+			if role, err := cx1client.GetRoleByName(roleName); err != nil {
+				logger.Errorf("Mapping %v: role %v does not exist in Cx1: %s", m.String(), roleName, err)
+			} else {
+				roleRef := role.Name
+				if role.ClientRole {
+					roleRef = "ast-app." + role.Name
+				}
+				mappers = append(mappers, iam.IdPMapper{
+					Name:   fmt.Sprintf("%v to role %v", m.Claim, role.Name),
+					Alias:  idp.Alias,
+					Mapper: "saml-advanced-role-idp-mapper",
+					Config: map[string]string{
+						"syncMode":                   "FORCE",
+						"attributes":                 string(attributes),
+						"are.attribute.values.regex": "false",
+						"role":                       roleRef,
+					},
+				})
+			}
+		}
+	}
+
+	return mappers
+}
+
+// mapperExists checks for an existing mapper of the same type with the same claim and target, regardless of its name
+func mapperExists(existing []iam.IdPMapper, mapper iam.IdPMapper) bool {
+	for _, e := range existing {
+		if e.Mapper == mapper.Mapper && e.Config["attributes"] == mapper.Config["attributes"] && e.Config["group"] == mapper.Config["group"] && e.Config["role"] == mapper.Config["role"] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cxpsemea/cx1_go_scripts/iam"
+)
+
+// Cx1ClientGo can create a user with a federated identity, but cannot link an identity to an existing user.
+// These requests are sent to the IAM admin API directly.
+type FederatedIdentity struct {
+	IdentityProvider string `json:"identityProvider"`
+	UserID           string `json:"userId"`
+	UserName         string `json:"userName"`
+}
+
+// IAMClient adds the federated identity requests to the shared IAM client
+type IAMClient struct {
+	*iam.Client
+}
+
+func NewIAMClient(client *http.Client) (*IAMClient, error) {
+	c, err := iam.NewClient(client)
+	if err != nil {
+		return nil, err
+	}
+	return &IAMClient{c}, nil
+}
+
+func (c IAMClient) GetFederatedIdentities(userId string) ([]FederatedIdentity, error) {
+	var identities []FederatedIdentity
+	data, err := c.SendRequest(http.MethodGet, fmt.Sprintf("/users/%v/federated-identity", userId), nil)
+	if err != nil {
+		return identities, err
+	}
+
+	err = json.Unmarshal(data, &identities)
+	return identities, err
+}
+
+func (c IAMClient) AddFederatedIdentity(userId string, identity FederatedIdentity) error {
+	jsonBody, _ := json.Marshal(identity)
+	_, err := c.SendRequest(http.MethodPost, fmt.Sprintf("/users/%v/federated-identity/%v", userId, url.PathEscape(identity.IdentityProvider)), bytes.NewReader(jsonBody))
+	return err
+}
+
+func (f FederatedIdentity) String() string {
+	return fmt.Sprintf("%v user %v (%v)", f.IdentityProvider, f.UserID, f.UserName)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+)
+
+// linkUsers attaches an IdP identity to each existing local user instead of re-creating them, so that the user ID, group membership and history are kept.
+// The group IDs of each linked user are returned, to be compared in the verification pass.
+func linkUsers(cx1client *Cx1ClientGo.Cx1Client, iamclient *IAMClient, rows []SAMLUserRow, update bool, logger *logrus.Logger) map[int][]string {
+	groupsBefore := make(map[int][]string)
+	linked, skipped, failed := 0, 0, 0
+
+	for _, row := range rows {
+		logger.Infof("Processing %v", row.String())
+		identity := FederatedIdentity{
+			IdentityProvider: row.IdPAlias,
+			UserID:           row.IdPUserID,
+			UserName:         row.IdPUserName,
+		}
+
+		user, err := findUser(cx1client, row)
+		if err != nil {
+			logger.Errorf("Failed to find existing user for %v: %s", row.String(), err)
+			failed++
+			continue
+		}
+
+		identities, err := iamclient.GetFederatedIdentities(user.UserID)
+		if err != nil {
+			logger.Errorf("Failed to get linked identities for user %v: %s", user.String(), err)
+			failed++
+			continue
+		}
+
+		if i := slices.IndexFunc(identities, func(f FederatedIdentity) bool { return f.IdentityProvider == row.IdPAlias }); i >= 0 {
+			if identities[i].UserID == row.IdPUserID {
+				logger.Infof(" - user %v is already linked to %v", user.String(), identities[i].String())
+				skipped++
+			} else {
+				logger.Errorf("User %v is already linked to a different identity %v, expected %v", user.String(), identities[i].String(), identity.String())
+				failed++
+			}
+			continue
+		}
+
+		groups, err := cx1client.GetUserGroups(&user)
+		if err != nil {
+			logger.Errorf("Failed to get groups for user %v: %s", user.String(), err)
+			failed++
+			continue
+		}
+		groupsBefore[row.Line] = groupIDs(groups)
+
+		if !update {
+			logger.Infof(" - would link user %v to %v", user.String(), identity.String())
+			linked++
+			continue
+		}
+
+		if err = iamclient.AddFederatedIdentity(user.UserID, identity); err != nil {
+			logger.Errorf("Failed to link user %v to %v: %s", user.String(), identity.String(), err)
+			failed++
+		} else {
+			logger.Infof(" - linked user %v to %v", user.String(), identity.String())
+			linked++
+		}
+	}
+
+	if update {
+		logger.Infof("Linking finished - %d users linked, %d already linked, %d failed", linked, skipped, failed)
+	} else {
+		logger.Infof("Linking finished - %d users would be linked, %d already linked, %d failed", linked, skipped, failed)
+	}
+	return groupsBefore
+}
+
+// verifyLinks checks that every user in the file is enabled, linked to the expected IdP identity, and has kept the same groups
+func verifyLinks(cx1client *Cx1ClientGo.Cx1Client, iamclient *IAMClient, rows []SAMLUserRow, groupsBefore map[int][]string, logger *logrus.Logger) {
+	verified, failed := 0, 0
+
+	for _, row := range rows {
+		problems := []string{}
+
+		user, err := findUser(cx1client, row)
+		if err != nil {
+			logger.Errorf("Verification failed for %v: user not found: %s", row.String(), err)
+			failed++
+			continue
+		}
+
+		if !user.Enabled {
+			problems = append(problems, "user is disabled")
+		}
+
+		identities, err := iamclient.GetFederatedIdentities(user.UserID)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("failed to get linked identities: %s", err))
+		} else if !slices.ContainsFunc(identities, func(f FederatedIdentity) bool {
+			return f.IdentityProvider == row.IdPAlias && f.UserID == row.IdPUserID
+		}) {
+			problems = append(problems, fmt.Sprintf("not linked to %v user %v", row.IdPAlias, row.IdPUserID))
+		}
+
+		if before, ok := groupsBefore[row.Line]; ok {
+			groups, err := cx1client.GetUserGroups(&user)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("failed to get groups: %s", err))
+			} else if after := groupIDs(groups); !slices.Equal(before, after) {
+				problems = append(problems, fmt.Sprintf("group membership changed from %d to %d groups", len(before), len(after)))
+			}
+		}
+
+		if len(problems) > 0 {
+			logger.Errorf("Verification failed for user %v: %v", user.String(), problems)
+			failed++
+		} else {
+			logger.Infof("Verified user %v is linked to %v user %v", user.String(), row.IdPAlias, row.IdPUserID)
+			verified++
+		}
+	}
+
+	logger.Infof("Verification finished - %d users verified, %d failed", verified, failed)
+}
+
+func groupIDs(groups []Cx1ClientGo.Group) []string {
+	ids := []string{}
+	for _, g := range groups {
+		ids = append(ids, g.GroupID)
+	}
+	slices.Sort(ids)
+	return ids
+}
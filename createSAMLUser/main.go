@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"net/http"
 	"os"
+	"strings"
 
 	// "fmt"
 
@@ -21,7 +23,13 @@ func main() {
 	logger.SetOutput(os.Stdout)
 
 	logger.Info("Starting")
-	logger.Info("This is just an example showing how to create a SAML user using the Cx1 client. The unique ID hardcoded in this example is for a specific user in my own Keycloak IdP and will not work for anyone else. The user details and unique ID should be replaced appropriately.")
+	logger.Info("This tool creates SAML users in bulk from a CSV file. The IdP user IDs are the internal identifiers used within your SAML IdP and must be obtained from the IdP first.")
+
+	usersFile := flag.String("users", "users.csv", "CSV file with a header row and columns: username,email,firstname,lastname,idp_alias,idp_user_id[,idp_username],groups,roles - groups and roles are ;-separated")
+	existing := flag.String("existing", "skip", "What to do with users that already exist: 'skip' them, or 'update' their names and add missing groups & roles")
+	update := flag.Bool("update", false, "Apply the changes, otherwise only inform")
+	link := flag.Bool("link", false, "Link existing local users (found by email, or username if there is no email column) to their IdP identity instead of creating new users - needs columns username,idp_alias,idp_user_id")
+	verify := flag.Bool("verify", false, "With -link: only verify that the users in the file are linked to their IdP identity, without making changes")
 
 	httpClient := &http.Client{}
 
@@ -36,25 +44,137 @@ func main() {
 
 	logger.Infof("Connected with %v", cx1client.String())
 
-	user, err := cx1client.GetUserByEmail("groucho@cx.local")
-	if err == nil {
-		logger.Infof("User groucho@cx.local already exists, deleting")
-		err = cx1client.DeleteUser(&user)
+	*existing = strings.ToLower(*existing)
+	if *existing != "skip" && *existing != "update" {
+		logger.Fatalf("Invalid value for existing: %v, should be 'skip' or 'update'", *existing)
+	}
+
+	if *link {
+		linkExistingUsers(cx1client, httpClient, *usersFile, *update, *verify, logger)
+		return
+	}
+
+	rows, err := ReadUsersFile(*usersFile, []string{"username", "email", "idp_alias", "idp_user_id"})
+	if err != nil {
+		logger.Fatalf("Failed to read users file %v: %s", *usersFile, err)
+	}
+	logger.Infof("Read %d users from %v", len(rows), *usersFile)
+
+	if *update {
+		logger.Warn("The 'update' flag is set - users will be created and updated")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	created, incomplete, updated, skipped, failed := 0, 0, 0, 0, 0
+
+	for _, row := range rows {
+		logger.Infof("Processing %v", row.String())
+
+		user, err := findUser(cx1client, row)
+		if err == nil {
+			if *existing == "skip" {
+				logger.Infof(" - user %v already exists, skipping", user.String())
+				skipped++
+				continue
+			}
+
+			logger.Infof(" - user %v already exists, updating", user.String())
+			if row.FirstName != "" && row.LastName != "" && (user.FirstName != row.FirstName || user.LastName != row.LastName) {
+				user.FirstName = row.FirstName
+				user.LastName = row.LastName
+				if !*update {
+					logger.Infof(" - would set name to %v %v", row.FirstName, row.LastName)
+				} else if err = cx1client.UpdateUser(&user); err != nil {
+					logger.Errorf("Failed to update user %v: %s", user.String(), err)
+					failed++
+					continue
+				} else {
+					logger.Infof(" - set name to %v %v", row.FirstName, row.LastName)
+				}
+			}
+
+			if err = assignGroupsAndRoles(cx1client, &user, row, *update, logger); err != nil {
+				logger.Errorf("Failed to update groups & roles for user %v: %s", user.String(), err)
+				failed++
+			} else {
+				updated++
+			}
+			continue
+		}
+
+		user = Cx1ClientGo.User{
+			Enabled:   true,
+			FirstName: row.FirstName,
+			LastName:  row.LastName,
+			UserName:  row.UserName,
+			Email:     row.Email,
+		}
+
+		if !*update {
+			logger.Infof(" - would create SAML user %v linked to %v user %v", row.UserName, row.IdPAlias, row.IdPUserID)
+			if err = assignGroupsAndRoles(cx1client, &user, row, false, logger); err != nil {
+				logger.Errorf("Invalid groups or roles for %v: %s", row.String(), err)
+				failed++
+			} else {
+				created++
+			}
+			continue
+		}
+
+		user, err = cx1client.CreateSAMLUser(user, row.IdPAlias, row.IdPUserID, row.IdPUserName)
 		if err != nil {
-			logger.Errorf("Failed to delete user groucho: %s", err)
+			logger.Errorf("Failed to create SAML user for %v: %s", row.String(), err)
+			failed++
+			continue
+		}
+		logger.Infof(" - created SAML user %v", user.String())
+
+		if err = assignGroupsAndRoles(cx1client, &user, row, true, logger); err != nil {
+			logger.Errorf("Failed to assign groups & roles for user %v: %s", user.String(), err)
+			incomplete++
+		} else {
+			created++
 		}
 	}
 
-	user = Cx1ClientGo.User{
-		Enabled:   true,
-		FirstName: "Groucho",
-		LastName:  "Marx",
-		UserName:  "groucho",
-		Email:     "groucho@cx.local",
+	if *update {
+		logger.Infof("Done - %d users created, %d created without all groups & roles, %d updated, %d skipped, %d failed", created, incomplete, updated, skipped, failed)
+	} else {
+		logger.Infof("Done - %d users would be created, %d updated, %d skipped, %d failed validation", created, updated, skipped, failed)
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+	}
+}
+
+func linkExistingUsers(cx1client *Cx1ClientGo.Cx1Client, httpClient *http.Client, usersFile string, update, verify bool, logger *logrus.Logger) {
+	rows, err := ReadUsersFile(usersFile, []string{"username", "idp_alias", "idp_user_id"})
+	if err != nil {
+		logger.Fatalf("Failed to read users file %v: %s", usersFile, err)
 	}
+	logger.Infof("Read %d users from %v", len(rows), usersFile)
 
-	user, err = cx1client.CreateSAMLUser(user, "dockerhost", "G-98cb32af-6f91-42fe-aad4-360c4420b8bb", "g-98cb32af-6f91-42fe-aad4-360c4420b8bb")
+	iamclient, err := NewIAMClient(httpClient)
 	if err != nil {
-		logger.Errorf("Failed to create SAML user: %s", err)
+		logger.Fatalf("Error creating IAM client: %s", err)
+	}
+
+	if verify {
+		verifyLinks(cx1client, iamclient, rows, map[int][]string{}, logger)
+		return
+	}
+
+	if update {
+		logger.Warn("The 'update' flag is set - existing users will be linked to their IdP identities")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	groupsBefore := linkUsers(cx1client, iamclient, rows, update, logger)
+
+	if update {
+		logger.Infof("Verifying linked users")
+		verifyLinks(cx1client, iamclient, rows, groupsBefore, logger)
+	} else {
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
 	}
 }
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+)
+
+// SAMLUserRow is one line of the users CSV. Groups and roles are semicolon-separated lists within their column.
+type SAMLUserRow struct {
+	Line        int
+	UserName    string
+	Email       string
+	FirstName   string
+	LastName    string
+	IdPAlias    string
+	IdPUserID   string
+	IdPUserName string
+	Groups      []string
+	Roles       []string
+}
+
+var groupCache = make(map[string]*Cx1ClientGo.Group)
+var roleCache = make(map[string]*Cx1ClientGo.Role)
+
+func (r SAMLUserRow) String() string {
+	return fmt.Sprintf("line %d: %v (%v)", r.Line, r.UserName, r.Email)
+}
+
+// ReadUsersFile reads a CSV with a header row naming the columns: username, email, firstname, lastname, idp_alias, idp_user_id, idp_username, groups, roles
+// Only the columns listed in required must be present, the idp_username defaults to the username.
+func ReadUsersFile(filename string, required []string) ([]SAMLUserRow, error) {
+	rows := []SAMLUserRow{}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return rows, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return rows, err
+	}
+	if len(records) == 0 {
+		return rows, fmt.Errorf("file %v is empty", filename)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return rows, fmt.Errorf("file %v is missing required column %v, the header row has: %v", filename, name, strings.Join(records[0], ","))
+		}
+	}
+
+	for i, record := range records[1:] {
+		get := func(name string) string {
+			if col, ok := columns[name]; ok {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+		list := func(name string) []string {
+			items := []string{}
+			for _, item := range strings.Split(get(name), ";") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items
+		}
+
+		row := SAMLUserRow{
+			Line:        i + 2,
+			UserName:    get("username"),
+			Email:       get("email"),
+			FirstName:   get("firstname"),
+			LastName:    get("lastname"),
+			IdPAlias:    get("idp_alias"),
+			IdPUserID:   get("idp_user_id"),
+			IdPUserName: get("idp_username"),
+			Groups:      list("groups"),
+			Roles:       list("roles"),
+		}
+		if row.IdPUserName == "" {
+			row.IdPUserName = row.UserName
+		}
+
+		for _, name := range required {
+			if get(name) == "" {
+				return rows, fmt.Errorf("line %d: required column %v is empty", row.Line, name)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// getGroup accepts a group path (starting with /) or a group name
+func getGroup(cx1client *Cx1ClientGo.Cx1Client, name string) (*Cx1ClientGo.Group, error) {
+	if group, ok := groupCache[name]; ok {
+		return group, nil
+	}
+
+	var group Cx1ClientGo.Group
+	var err error
+	if strings.HasPrefix(name, "/") {
+		group, err = cx1client.GetGroupByPath(name)
+	} else {
+		group, err = cx1client.GetGroupByName(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	groupCache[name] = &group
+	return &group, nil
+}
+
+func getRole(cx1client *Cx1ClientGo.Cx1Client, name string) (*Cx1ClientGo.Role, error) {
+	if role, ok := roleCache[name]; ok {
+		return role, nil
+	}
+
+	role, err := cx1client.GetRoleByName(strings.TrimPrefix(name, "ast-app."))
+	if err != nil {
+		return nil, err
+	}
+
+	roleCache[name] = &role
+	return &role, nil
+}
+
+// findUser looks up the user by email if one was provided, or otherwise by username
+func findUser(cx1client *Cx1ClientGo.Cx1Client, row SAMLUserRow) (Cx1ClientGo.User, error) {
+	if row.Email != "" {
+		return cx1client.GetUserByEmail(row.Email)
+	}
+	return cx1client.GetUserByUserName(row.UserName)
+}
+
+// assignGroupsAndRoles adds the user to any groups and roles from the row that they do not already have.
+// Groups and roles that the user has but are not listed in the row are left untouched.
+func assignGroupsAndRoles(cx1client *Cx1ClientGo.Cx1Client, user *Cx1ClientGo.User, row SAMLUserRow, update bool, logger *logrus.Logger) error {
+	var errs []string
+
+	if user.UserID != "" {
+		if _, err := cx1client.GetUserGroups(user); err != nil {
+			return fmt.Errorf("failed to get groups for user %v: %s", user.String(), err)
+		}
+		if _, err := cx1client.GetUserRoles(user); err != nil {
+			return fmt.Errorf("failed to get roles for user %v: %s", user.String(), err)
+		}
+	}
+
+	for _, name := range row.Groups {
+		group, err := getGroup(cx1client, name)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("group %v not found: %s", name, err))
+			continue
+		}
+
+		if slices.ContainsFunc(user.Groups, func(g Cx1ClientGo.Group) bool { return g.GroupID == group.GroupID }) {
+			logger.Debugf(" - user is already in group %v", group.Path)
+		} else if !update {
+			logger.Infof(" - would add user to group %v", group.Path)
+		} else if err = cx1client.AssignUserToGroupByID(user, group.GroupID); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to add user to group %v: %s", group.Path, err))
+		} else {
+			logger.Infof(" - added user to group %v", group.Path)
+		}
+	}
+
+	newRoles := []Cx1ClientGo.Role{}
+	for _, name := range row.Roles {
+		role, err := getRole(cx1client, name)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("role %v not found: %s", name, err))
+			continue
+		}
+
+		if slices.ContainsFunc(user.Roles, func(r Cx1ClientGo.Role) bool { return r.RoleID == role.RoleID }) {
+			logger.Debugf(" - user already has role %v", role.Name)
+		} else if !update {
+			logger.Infof(" - would add role %v", role.Name)
+		} else {
+			newRoles = append(newRoles, *role)
+		}
+	}
+
+	if len(newRoles) > 0 {
+		if err := cx1client.AddUserRoles(user, &newRoles); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to add roles: %s", err))
+		} else {
+			for _, r := range newRoles {
+				logger.Infof(" - added role %v", r.Name)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ go 1.21.6
 
 require (
 	github.com/cxpsemea/Cx1ClientGo v0.0.78
+	github.com/cxpsemea/cx1_go_scripts/bulk v0.0.0-00010101000000-000000000000
 	github.com/sirupsen/logrus v1.9.3
 	github.com/t-tomalak/logrus-easy-formatter v0.0.0-20190827215021-c074f06c5816
 )
@@ -17,3 +18,5 @@ require (
 	google.golang.org/appengine v1.6.8 // indirect
 	google.golang.org/protobuf v1.31.0 // indirect
 )
+
+replace github.com/cxpsemea/cx1_go_scripts/bulk => ../bulk
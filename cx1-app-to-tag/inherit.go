@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+type InheritConflict struct {
+	Project string
+	Key     string
+	Values  []string
+}
+
+// inheritApplicationTags copies application tags, and optionally the application criticality, onto every member project.
+// When a project is in several applications that have different values for the same key, the conflict policy decides which value is used.
+func inheritApplicationTags(cx1client *Cx1ClientGo.Cx1Client, inheritKeys string, inheritCriticality bool, policy string, sortApps, missingOnly, update bool) {
+	policy = strings.ToLower(policy)
+	if !slices.Contains([]string{"first", "highest", "join", "skip"}, policy) {
+		logger.Fatalf("Invalid conflict policy %v, should be 'first', 'highest', 'join' or 'skip'", policy)
+	}
+
+	allKeys := inheritKeys == "*"
+	keys := []string{}
+	if !allKeys {
+		for _, key := range strings.Split(inheritKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	if allKeys {
+		logger.Infof("Projects will inherit all tags from their applications")
+	} else if len(keys) > 0 {
+		logger.Infof("Projects will inherit the tags %v from their applications", strings.Join(keys, ", "))
+	}
+	if inheritCriticality {
+		logger.Infof("Projects will inherit the criticality of their applications")
+	}
+	logger.Infof("Conflicting values from multiple applications will be handled with the '%v' policy", policy)
+	if missingOnly {
+		logger.Infof("Will only set tags that the projects are missing, existing project tags will not be overwritten.")
+	}
+
+	if update {
+		logger.Warn("The 'update' flag is set - changes will be applied")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	logger.Infof("Connected with %v", cx1client.String())
+
+	projects, AppsByID := getProjectsAndApplications(cx1client)
+
+	conflicts := []InheritConflict{}
+	updated, failed := 0, 0
+
+	for _, project := range projects {
+		projectApps := []*Cx1ClientGo.Application{}
+		for _, id := range project.Applications {
+			if app, ok := AppsByID[id]; ok {
+				projectApps = append(projectApps, app)
+			} else {
+				logger.Errorf("Project %v is linked to unknown application with ID %v", project.String(), id)
+			}
+		}
+		if len(projectApps) == 0 {
+			continue
+		}
+
+		if sortApps {
+			slices.SortFunc(projectApps, func(a, b *Cx1ClientGo.Application) int { return strings.Compare(a.Name, b.Name) })
+		}
+
+		logger.Infof("Checking project: %v", project.String())
+		if project.Tags == nil {
+			project.Tags = make(map[string]string)
+		}
+		changed := false
+		before := maps.Clone(project.Tags)
+
+		projectKeys := keys
+		if allKeys {
+			projectKeys = []string{}
+			for _, app := range projectApps {
+				for key := range app.Tags {
+					if !slices.Contains(projectKeys, key) {
+						projectKeys = append(projectKeys, key)
+					}
+				}
+			}
+			slices.Sort(projectKeys)
+		}
+
+		for _, key := range projectKeys {
+			if _, ok := project.Tags[key]; ok && missingOnly {
+				continue
+			}
+
+			value, ok, conflict := resolveInheritedValue(projectApps, policy, func(app *Cx1ClientGo.Application) (string, bool) {
+				value, ok := app.Tags[key]
+				return value, ok
+			})
+			if conflict != nil {
+				conflict.Project = project.String()
+				conflict.Key = key
+				conflicts = append(conflicts, *conflict)
+				logger.Warnf(" - Skipping tag %v: applications have conflicting values %v", key, strings.Join(conflict.Values, ", "))
+				continue
+			}
+
+			if ok && project.Tags[key] != value {
+				logger.Infof(" - Setting tag: %v = %v (was: '%v')", key, value, project.Tags[key])
+				project.Tags[key] = value
+				changed = true
+			}
+		}
+
+		if inheritCriticality {
+			criticalityPolicy := policy
+			if policy == "join" {
+				criticalityPolicy = "highest"
+			}
+			value, _, conflict := resolveInheritedValue(projectApps, criticalityPolicy, func(app *Cx1ClientGo.Application) (string, bool) {
+				return strconv.FormatUint(uint64(app.Criticality), 10), true
+			})
+			if conflict != nil {
+				conflict.Project = project.String()
+				conflict.Key = "criticality"
+				conflicts = append(conflicts, *conflict)
+				logger.Warnf(" - Skipping criticality: applications have conflicting values %v", strings.Join(conflict.Values, ", "))
+			} else if criticality, _ := strconv.ParseUint(value, 10, 32); uint(criticality) != project.Criticality {
+				logger.Infof(" - Setting criticality: %d (was: %d)", criticality, project.Criticality)
+				project.Criticality = uint(criticality)
+				changed = true
+			}
+		}
+
+		if !changed {
+			logger.Infof(" - Project %v required no changes.", project.String())
+		} else if !update {
+			logger.Infof(" - Not applying changes, use --update to apply.")
+			updated++
+		} else if err := recordTags(&project, before); err != nil {
+			logger.Fatalf("Failed to record previous tags of project %v in %v: %s", project.String(), revertLog.Filename, err)
+		} else if err := cx1client.UpdateProject(&project); err != nil {
+			logger.Errorf("Failed to update project %v with inherited application tags: %s", project.String(), err)
+			failed++
+		} else {
+			logger.Infof(" - Updated project %v with inherited application tags: %v", project.String(), project.Tags)
+			updated++
+		}
+	}
+
+	if len(conflicts) > 0 {
+		logger.Warnf("%d tags were skipped due to conflicting values between applications:", len(conflicts))
+		for _, c := range conflicts {
+			logger.Warnf(" - project %v, %v: %v", c.Project, c.Key, strings.Join(c.Values, ", "))
+		}
+	}
+
+	if update {
+		logger.Infof("Done - %d projects updated, %d failed", updated, failed)
+	} else {
+		logger.Infof("Done - %d projects would be updated", updated)
+		logger.Warnf("No changes were applied. To apply changes, re-run with the --update flag set.")
+	}
+}
+
+// resolveInheritedValue picks the value for one key from the applications that have it, according to the conflict policy.
+// Returns ok=false if no application has the key, or a conflict if the values differ and the policy is 'skip'.
+func resolveInheritedValue(apps []*Cx1ClientGo.Application, policy string, get func(*Cx1ClientGo.Application) (string, bool)) (string, bool, *InheritConflict) {
+	values := []string{}
+	described := []string{}
+	var highest *Cx1ClientGo.Application
+	highestValue := ""
+
+	for _, app := range apps {
+		value, ok := get(app)
+		if !ok {
+			continue
+		}
+		if highest == nil || app.Criticality > highest.Criticality {
+			highest = app
+			highestValue = value
+		}
+		described = append(described, fmt.Sprintf("%v=%v", app.Name, value))
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return "", false, nil
+	}
+	if len(values) == 1 {
+		return values[0], true, nil
+	}
+
+	switch policy {
+	case "first":
+		return values[0], true, nil
+	case "highest":
+		return highestValue, true, nil
+	case "join":
+		return strings.Join(values, ","), true, nil
+	}
+	return "", false, &InheritConflict{Values: described}
+}
@@ -3,18 +3,22 @@ package main
 import (
 	"flag"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
 	"regexp"
 	"slices"
 	"strings"
+	"text/template"
 
 	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/bulk"
 	"github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
 
 var logger *logrus.Logger
+var revertLog *bulk.RevertLog
 
 func main() {
 	logger = logrus.New()
@@ -36,17 +40,69 @@ func main() {
 	Clean := flag.Bool("clean", false, "If true: previous tags matching the provided tag key will be removed, otherwise they will be left untouched but may be overwritten by new values.")
 	Change := flag.Bool("update", false, "No changes will be made unless this flag is set to true")
 	MissingOnly := flag.Bool("missing", false, "Update only the projects that are missing the tag - useful if errors caused a few to fail, use this flag to fill the gaps")
+	KeyTemplate := flag.String("key-template", "", "Optional: Go text/template for the tag key, rendered per application - eg: 'app-{{.Name}}'. Replaces -tag and -separate, applications producing the same key are comma-joined.")
+	InheritKeys := flag.String("inherit", "", "Sync mode: comma-separated list of application tag keys to copy onto each member project, or * for all keys. The other tagging flags are ignored in this mode.")
+	InheritCriticality := flag.Bool("inherit-criticality", false, "Sync mode: copy the application criticality onto each member project")
+	Conflict := flag.String("conflict", "skip", "Sync mode: how to handle projects in several applications with different values - 'first' application (see -sort), 'highest' criticality application, 'join' all values, or 'skip' and report")
+	RevertLogFile := flag.String("revert-log", "", "File to record each project's tags before they are changed (default: app-to-tag-revert-<timestamp>.jsonl)")
+	RevertFile := flag.String("revert", "", "Revert mode: put back the project tags recorded in this revert file, skipping projects changed since. The other flags except -update are ignored in this mode.")
+	RevertKeys := flag.String("revert-keys", "", "Revert mode: optional comma-separated list of tag keys to revert, other keys are left as they are")
+	ValueTemplate := flag.String("value-template", "{{.Name}}", "Go text/template for the tag value, rendered per application - eg: '{{.Criticality}}' or '{{index .Tags \"business-unit\"}}'. Available: all Application fields, plus {{.Index}} for the position of the application.")
 
 	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
 	if err != nil {
 		logger.Fatalf("Error creating client: %s", err)
 	}
 
-	if *TagName == "" {
+	if *RevertFile != "" {
+		keys := []string{}
+		for _, key := range strings.Split(*RevertKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		bulk.RevertTags(projectTagger{cx1client}, *RevertFile, keys, *Change, logger)
+		return
+	}
+
+	if *Change {
+		if *RevertLogFile == "" {
+			*RevertLogFile = bulk.RevertFilename("app-to-tag")
+		}
+		if revertLog, err = bulk.OpenRevertLog(*RevertLogFile); err != nil {
+			logger.Fatalf("Failed to open revert file %v: %s", *RevertLogFile, err)
+		}
+		defer revertLog.Close()
+		logger.Infof("Previous project tags will be recorded in %v - use -revert %v to undo the changes", *RevertLogFile, *RevertLogFile)
+	}
+
+	if *InheritKeys != "" || *InheritCriticality {
+		inheritApplicationTags(cx1client, *InheritKeys, *InheritCriticality, *Conflict, *Sort, *MissingOnly, *Change)
+		return
+	}
+
+	if *TagName == "" && *KeyTemplate == "" {
 		logger.Fatalf("Tag parameter must be set")
 	}
 
-	if *SeparateTags {
+	valueTmpl, err := parseTemplate("value", *ValueTemplate)
+	if err != nil {
+		logger.Fatalf("Failed to parse value template %v: %s", *ValueTemplate, err)
+	}
+
+	var keyTmpl *template.Template
+	if *KeyTemplate != "" {
+		if keyTmpl, err = parseTemplate("key", *KeyTemplate); err != nil {
+			logger.Fatalf("Failed to parse key template %v: %s", *KeyTemplate, err)
+		}
+	}
+
+	if *KeyTemplate != "" {
+		logger.Infof("Projects will receive new tags with keys from template %v and values from template %v for each linked application", *KeyTemplate, *ValueTemplate)
+		if *SeparateTags {
+			logger.Warnf("The 'separate' flag is ignored when a key template is used")
+		}
+	} else if *SeparateTags {
 		logger.Infof("Projects will receive new tags named %v_1 ... %v_n for each linked application", *TagName, *TagName)
 	} else {
 		logger.Infof("Projects will receive a new tag named %v with all linked applications in a comma-separated list", *TagName)
@@ -65,10 +121,18 @@ func main() {
 	}
 
 	if *Clean {
-		logger.Infof("Previous tags with the key %v, or with a key matching %v_#, will be removed.", *TagName, *TagName)
+		if *KeyTemplate != "" {
+			logger.Infof("Previous tags with a key that the key template produces for any application will be removed, other tags are left untouched.")
+		} else {
+			logger.Infof("Previous tags with the key %v, or with a key matching %v_#, will be removed.", *TagName, *TagName)
+		}
 	}
 	if *MissingOnly {
-		logger.Infof("Will only update the projects that are missing the %v tag.", *TagName)
+		if *KeyTemplate != "" {
+			logger.Infof("Will only update the projects that are missing a tag with a key that the key template produces for any application.")
+		} else {
+			logger.Infof("Will only update the projects that are missing the %v tag.", *TagName)
+		}
 		if *Clean {
 			logger.Errorf("The 'missing' tag is incompatible with the 'clean' tag - disabling 'clean'")
 			*Clean = false
@@ -85,40 +149,44 @@ func main() {
 
 	logger.Infof("Connected with %v", cx1client.String())
 
-	projcount, err := cx1client.GetProjectCount()
-	if err != nil {
-		logger.Fatalf("Failed to get project count: %s", err)
-	}
-	projects, err := cx1client.GetProjects(projcount)
-	if err != nil {
-		logger.Fatalf("Failed to get projects: %s", err)
-	}
+	projects, AppsByID := getProjectsAndApplications(cx1client)
 
-	appcount, err := cx1client.GetApplicationCount()
-	if err != nil {
-		logger.Fatalf("Failed to get application count: %s", err)
-	}
-	apps, err := cx1client.GetApplications(uint(appcount))
-	if err != nil {
-		logger.Fatalf("Failed to get applications: %s", err)
+	keyRE := regexp.MustCompile(*TagName + "_[0-9]+")
+	isAppTag := func(key string) bool {
+		return key == *TagName || keyRE.MatchString(key)
 	}
-
-	AppsByID := make(map[string]*Cx1ClientGo.Application)
-	for id, app := range apps {
-		AppsByID[app.ApplicationID] = &apps[id]
+	if *KeyTemplate != "" && (*Clean || *MissingOnly) {
+		// only keys this run could have produced, so that unrelated tags sharing a prefix with the template (eg: app-owner for app-{{.Name}}) are kept
+		positions := *MaxApps
+		if positions == 0 {
+			for _, project := range projects {
+				positions = max(positions, len(project.Applications))
+			}
+		}
+		templateKeySet, err := templateKeys(keyTmpl, AppsByID, positions)
+		if err != nil {
+			logger.Fatalf("Failed to render key template %v: %s", *KeyTemplate, err)
+		}
+		keys := []string{}
+		for key := range templateKeySet {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		logger.Infof("The key template produces %d tag keys for the current applications: %v", len(keys), strings.Join(keys, ", "))
+		isAppTag = func(key string) bool {
+			return templateKeySet[key]
+		}
 	}
 
-	keyRE := regexp.MustCompile(*TagName + "_[0-9]+")
-
 	for _, project := range projects {
 		logger.Infof("Checking project: %v", project.String())
-		names := []string{}
 		changed := false
+		before := maps.Clone(project.Tags)
 
 		hasTagAlready := false
 
 		for key := range project.Tags {
-			if key == *TagName || keyRE.MatchString(key) {
+			if isAppTag(key) {
 				if *Clean {
 					logger.Infof(" - Removing existing tag: %v = %v", key, project.Tags[key])
 					delete(project.Tags, key)
@@ -132,48 +200,90 @@ func main() {
 		if *MissingOnly && hasTagAlready {
 			logger.Infof(" - Skipping project because the 'missing' flag was set and this project already has a matching tag.")
 		} else {
-
+			projectApps := []*Cx1ClientGo.Application{}
+			newTags := make(map[string]string)
 			for _, id := range project.Applications {
 				if val, ok := AppsByID[id]; ok {
-					names = append(names, val.Name)
-					changed = true
+					projectApps = append(projectApps, val)
 				} else {
 					logger.Errorf("Project %v is linked to unknown application with ID %v", project.String(), id)
 				}
 			}
 
-			if len(names) == 0 {
+			if len(projectApps) == 0 {
 				if len(project.Applications) > 0 {
 					logger.Errorf("Project %v is linked to %d applications, but none of these were found", project.String(), len(project.Applications))
 				}
 			} else {
 				if *Sort {
-					slices.Sort(names)
+					slices.SortFunc(projectApps, func(a, b *Cx1ClientGo.Application) int { return strings.Compare(a.Name, b.Name) })
 				}
 
-				max := len(names)
-				if max > *MaxApps && *MaxApps != 0 {
-					max = *MaxApps
-					names = names[:max]
+				if len(projectApps) > *MaxApps && *MaxApps != 0 {
+					projectApps = projectApps[:*MaxApps]
 				}
 
-				if *SeparateTags {
-					for i := 0; i < max; i++ {
-						tagKey := fmt.Sprintf("%v_%d", *TagName, i+1)
-						project.Tags[tagKey] = names[i]
-						logger.Infof(" - Adding new tag: %v = %v", tagKey, names[i])
-						changed = true
+				if *KeyTemplate != "" {
+					for i, app := range projectApps {
+						tagKey, err := renderTemplate(keyTmpl, app, i+1)
+						if err != nil {
+							logger.Errorf("Failed to render key template for application %v: %s", app.String(), err)
+							continue
+						}
+						tagValue, err := renderTemplate(valueTmpl, app, i+1)
+						if err != nil {
+							logger.Errorf("Failed to render value template for application %v: %s", app.String(), err)
+							continue
+						}
+						if tagKey == "" {
+							logger.Warnf(" - Key template produced an empty key for application %v, skipping", app.String())
+							continue
+						}
+						if values, ok := newTags[tagKey]; ok {
+							if !slices.Contains(strings.Split(values, ","), tagValue) {
+								newTags[tagKey] = values + "," + tagValue
+							}
+						} else {
+							newTags[tagKey] = tagValue
+						}
 					}
 				} else {
-					tagValue := strings.Join(names, ",")
-					project.Tags[*TagName] = tagValue
-					logger.Infof(" - Adding new tag: %v = %v", *TagName, tagValue)
+					values := []string{}
+					for i, app := range projectApps {
+						tagValue, err := renderTemplate(valueTmpl, app, i+1)
+						if err != nil {
+							logger.Errorf("Failed to render value template for application %v: %s", app.String(), err)
+						} else if tagValue != "" {
+							values = append(values, tagValue)
+						}
+					}
+
+					if *SeparateTags {
+						for i, tagValue := range values {
+							newTags[fmt.Sprintf("%v_%d", *TagName, i+1)] = tagValue
+						}
+					} else if len(values) > 0 {
+						newTags[*TagName] = strings.Join(values, ",")
+					}
+				}
+
+				tagKeys := []string{}
+				for tagKey := range newTags {
+					tagKeys = append(tagKeys, tagKey)
+				}
+				slices.Sort(tagKeys)
+				for _, tagKey := range tagKeys {
+					project.Tags[tagKey] = newTags[tagKey]
+					logger.Infof(" - Adding new tag: %v = %v", tagKey, newTags[tagKey])
 					changed = true
 				}
 			}
 
 			if changed {
 				if *Change {
+					if err = recordTags(&project, before); err != nil {
+						logger.Fatalf("Failed to record previous tags of project %v in %v: %s", project.String(), revertLog.Filename, err)
+					}
 					if err = cx1client.UpdateProject(&project); err != nil {
 						logger.Errorf("Failed to update project %v with new application tags: %s", project.String(), err)
 					} else {
@@ -193,3 +303,57 @@ func main() {
 		logger.Warnf("No changes were applied. To apply changes, re-run with the --update flag set.")
 	}
 }
+
+func getProjectsAndApplications(cx1client *Cx1ClientGo.Cx1Client) ([]Cx1ClientGo.Project, map[string]*Cx1ClientGo.Application) {
+	projcount, err := cx1client.GetProjectCount()
+	if err != nil {
+		logger.Fatalf("Failed to get project count: %s", err)
+	}
+	projects, err := cx1client.GetProjects(projcount)
+	if err != nil {
+		logger.Fatalf("Failed to get projects: %s", err)
+	}
+
+	appcount, err := cx1client.GetApplicationCount()
+	if err != nil {
+		logger.Fatalf("Failed to get application count: %s", err)
+	}
+	apps, err := cx1client.GetApplications(uint(appcount))
+	if err != nil {
+		logger.Fatalf("Failed to get applications: %s", err)
+	}
+
+	AppsByID := make(map[string]*Cx1ClientGo.Application)
+	for id, app := range apps {
+		AppsByID[app.ApplicationID] = &apps[id]
+	}
+
+	return projects, AppsByID
+}
+
+// recordTags adds the project's tags before and after the change to the revert file, if one is open
+func recordTags(project *Cx1ClientGo.Project, before map[string]string) error {
+	if revertLog == nil {
+		return nil
+	}
+	return revertLog.Record(project.ProjectID, project.Name, before, project.Tags)
+}
+
+// projectTagger lets bulk.RevertTags read and write project tags
+type projectTagger struct {
+	cx1client *Cx1ClientGo.Cx1Client
+}
+
+func (t projectTagger) GetProjectTags(projectID string) (string, map[string]string, error) {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	return project.String(), project.Tags, err
+}
+
+func (t projectTagger) SetProjectTags(projectID string, tags map[string]string) error {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	if err != nil {
+		return err
+	}
+	project.Tags = tags
+	return t.cx1client.UpdateProject(&project)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// TagData is passed to the key and value templates: all Application fields are available (eg: {{.Name}}, {{.Criticality}}, {{index .Tags "business-unit"}})
+// along with the 1-based position of the application in the project's list of applications as {{.Index}}
+type TagData struct {
+	*Cx1ClientGo.Application
+	Index int
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=zero").Parse(text)
+}
+
+func renderTemplate(tmpl *template.Template, app *Cx1ClientGo.Application, index int) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, TagData{app, index}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// templateKeys renders the key template for every application at every position it can have in a project's list of applications,
+// which are all the tag keys the template can produce in this run
+func templateKeys(tmpl *template.Template, apps map[string]*Cx1ClientGo.Application, positions int) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	for _, app := range apps {
+		for index := 1; index <= positions; index++ {
+			key, err := renderTemplate(tmpl, app, index)
+			if err != nil {
+				return keys, fmt.Errorf("application %v: %s", app.String(), err)
+			}
+			if key != "" {
+				keys[key] = true
+			}
+		}
+	}
+	return keys, nil
+}
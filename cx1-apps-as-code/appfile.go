@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"gopkg.in/yaml.v3"
+)
+
+type AppRule struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+}
+
+// AppDefinition is the content of one application YAML file. Projects listed explicitly are added to a "project.name.in" rule.
+type AppDefinition struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Criticality uint              `yaml:"criticality,omitempty"`
+	Tags        map[string]string `yaml:"tags,omitempty"`
+	Rules       []AppRule         `yaml:"rules,omitempty"`
+	Projects    []string          `yaml:"projects,omitempty"`
+
+	File string `yaml:"-"`
+}
+
+var unsafeFilenameRE = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ReadAppDefinitions reads every .yaml/.yml file in the directory, one application per file
+func ReadAppDefinitions(dir string) ([]AppDefinition, error) {
+	defs := []AppDefinition{}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return defs, err
+	}
+
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		filename := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return defs, err
+		}
+
+		var def AppDefinition
+		if err = yaml.Unmarshal(data, &def); err != nil {
+			return defs, fmt.Errorf("failed to parse %v: %s", filename, err)
+		}
+		def.File = filename
+
+		if def.Name == "" {
+			return defs, fmt.Errorf("%v does not define an application name", filename)
+		}
+		if def.Criticality == 0 {
+			def.Criticality = 3
+		}
+		if i := slices.IndexFunc(defs, func(d AppDefinition) bool { return d.Name == def.Name }); i >= 0 {
+			return defs, fmt.Errorf("application %v is defined in both %v and %v", def.Name, defs[i].File, filename)
+		}
+
+		defs = append(defs, def)
+	}
+
+	return defs, nil
+}
+
+// WriteAppDefinition writes the application to <dir>/<name>.yaml and returns the file name.
+// Names that end up as the same file name (eg: "A/B" and "A B") get a numbered suffix, tracked in used.
+func WriteAppDefinition(dir string, app *Cx1ClientGo.Application, used map[string]bool) (string, error) {
+	def := AppDefinition{
+		Name:        app.Name,
+		Description: app.Description,
+		Criticality: app.Criticality,
+		Tags:        app.Tags,
+	}
+	for _, r := range app.Rules {
+		def.Rules = append(def.Rules, AppRule{r.Type, r.Value})
+	}
+
+	data, err := yaml.Marshal(def)
+	if err != nil {
+		return "", err
+	}
+
+	base := definitionName(app.Name)
+	name := base
+	for i := 2; used[strings.ToLower(name)]; i++ { // case insensitive, for Windows and macOS file systems
+		name = fmt.Sprintf("%v_%d", base, i)
+	}
+	used[strings.ToLower(name)] = true
+
+	filename := filepath.Join(dir, name+".yaml")
+	return filename, os.WriteFile(filename, data, 0644)
+}
+
+// StaleDefinitions returns the .yaml/.yml files in the directory that are not in written, eg: left over from applications that were renamed or deleted since an earlier export
+func StaleDefinitions(dir string, written []string) ([]string, error) {
+	stale := []string{}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return stale, err
+	}
+
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		filename := filepath.Join(dir, entry.Name())
+		if !slices.Contains(written, filename) {
+			stale = append(stale, filename)
+		}
+	}
+
+	return stale, nil
+}
+
+// definitionName is the file name for an application, without the extension
+func definitionName(name string) string {
+	return unsafeFilenameRE.ReplaceAllString(name, "_")
+}
+
+// Desired returns the application as it should be in Cx1. Rules are kept as written, only the explicit projects are merged into a "project.name.in" rule
+func (d AppDefinition) Desired() Cx1ClientGo.Application {
+	app := Cx1ClientGo.Application{
+		Name:        d.Name,
+		Description: d.Description,
+		Criticality: d.Criticality,
+		Tags:        d.Tags,
+		Rules:       []Cx1ClientGo.ApplicationRule{},
+	}
+	if app.Tags == nil {
+		app.Tags = map[string]string{}
+	}
+
+	for _, r := range d.Rules {
+		app.Rules = append(app.Rules, Cx1ClientGo.ApplicationRule{Type: r.Type, Value: r.Value})
+	}
+	for _, name := range d.Projects {
+		app.AssignProject(&Cx1ClientGo.Project{Name: name})
+	}
+
+	return app
+}
+
+// normalizeRules sorts the rules and the values within each rule, so that rule sets can be compared regardless of order
+func normalizeRules(rules []Cx1ClientGo.ApplicationRule) []string {
+	normalized := []string{}
+	for _, r := range rules {
+		values := strings.Split(r.Value, ";")
+		if r.Type != "project.name.regex" {
+			slices.Sort(values)
+		}
+		normalized = append(normalized, fmt.Sprintf("%v: %v", r.Type, strings.Join(values, ";")))
+	}
+	slices.Sort(normalized)
+	return normalized
+}
@@ -0,0 +1,18 @@
+module github.com/cxpsemea/cx1_go_scripts/cx1-apps-as-code
+
+go 1.22.0
+
+require (
+	github.com/cxpsemea/Cx1ClientGo v0.0.95
+	github.com/sirupsen/logrus v1.9.3
+	github.com/t-tomalak/logrus-easy-formatter v0.0.0-20190827215021-c074f06c5816
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/golang-jwt/jwt/v4 v4.5.1 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f // indirect
+	golang.org/x/oauth2 v0.24.0 // indirect
+	golang.org/x/sys v0.27.0 // indirect
+)
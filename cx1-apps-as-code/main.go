@@ -0,0 +1,252 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+var logger *logrus.Logger
+
+type PlanItem struct {
+	Action  string
+	Name    string
+	Changes []string
+	Desired Cx1ClientGo.Application
+	Current *Cx1ClientGo.Application
+}
+
+func main() {
+	logger = logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	logger.Info("Starting")
+	logger.Info("The purpose of this tool is to keep CheckmarxOne applications in sync with YAML definitions, one file per application.")
+
+	AppsDir := flag.String("dir", "applications", "Directory containing the application YAML files")
+	Export := flag.Bool("export", false, "Write the tenant's current applications to the directory as YAML files, instead of applying them")
+	Delete := flag.Bool("delete", false, "Delete applications that exist in CheckmarxOne but have no YAML file")
+	Update := flag.Bool("update", false, "Apply the plan, otherwise only show it")
+
+	httpClient := &http.Client{}
+	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
+	if err != nil {
+		logger.Fatalf("Error creating client: %s", err)
+	}
+	logger.Infof("Connected with %v", cx1client.String())
+
+	apps, err := cx1client.GetAllApplications()
+	if err != nil {
+		logger.Fatalf("Failed to get applications: %s", err)
+	}
+
+	if *Export {
+		if err = os.MkdirAll(*AppsDir, 0755); err != nil {
+			logger.Fatalf("Failed to create directory %v: %s", *AppsDir, err)
+		}
+		slices.SortFunc(apps, func(a, b Cx1ClientGo.Application) int { return strings.Compare(a.Name, b.Name) })
+		used := make(map[string]bool)
+		written := []string{}
+		for id := range apps {
+			filename, err := WriteAppDefinition(*AppsDir, &apps[id], used)
+			if err != nil {
+				logger.Fatalf("Failed to export application %v: %s", apps[id].String(), err)
+			}
+			written = append(written, filename)
+			if filepath.Base(filename) != definitionName(apps[id].Name)+".yaml" {
+				logger.Warnf("Application %v has the same file name as another application, it is exported to %v instead", apps[id].String(), filename)
+			} else {
+				logger.Infof("Exported application %v to %v", apps[id].String(), filename)
+			}
+		}
+		logger.Infof("Exported %d applications to %v", len(apps), *AppsDir)
+
+		// files from an earlier export would otherwise re-create applications that were since renamed or deleted
+		stale, err := StaleDefinitions(*AppsDir, written)
+		if err != nil {
+			logger.Fatalf("Failed to list %v: %s", *AppsDir, err)
+		}
+		for _, filename := range stale {
+			if err = os.Remove(filename); err != nil {
+				logger.Errorf("Failed to remove %v, which does not match any current application: %s", filename, err)
+			} else {
+				logger.Warnf("Removed %v, which does not match any current application", filename)
+			}
+		}
+		return
+	}
+
+	defs, err := ReadAppDefinitions(*AppsDir)
+	if err != nil {
+		logger.Fatalf("Failed to read application definitions from %v: %s", *AppsDir, err)
+	}
+	logger.Infof("Read %d application definitions from %v", len(defs), *AppsDir)
+
+	projects, err := cx1client.GetAllProjects()
+	if err != nil {
+		logger.Fatalf("Failed to get projects: %s", err)
+	}
+	for _, def := range defs {
+		for _, name := range def.Projects {
+			if !slices.ContainsFunc(projects, func(p Cx1ClientGo.Project) bool { return p.Name == name }) {
+				logger.Warnf("Application %v in %v lists project %v which does not exist", def.Name, def.File, name)
+			}
+		}
+	}
+
+	plan := makePlan(apps, defs, *Delete)
+
+	if *Update {
+		logger.Warn("The 'update' flag is set - the plan will be applied")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	logger.Infof("Plan:")
+	counts := make(map[string]int)
+	for _, item := range plan {
+		counts[item.Action]++
+		if item.Action == "unchanged" {
+			logger.Debugf("  %v: %v", item.Action, item.Name)
+			continue
+		}
+		logger.Infof("  %v: %v", item.Action, item.Name)
+		for _, c := range item.Changes {
+			logger.Infof("      %v", c)
+		}
+	}
+	logger.Infof("Plan: %d to create, %d to update, %d to delete, %d unchanged, %d not managed", counts["create"], counts["update"], counts["delete"], counts["unchanged"], counts["unmanaged"])
+
+	if !*Update {
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+		return
+	}
+
+	failed := 0
+	for _, item := range plan {
+		if err := applyPlanItem(cx1client, item); err != nil {
+			logger.Errorf("Failed to %v application %v: %s", item.Action, item.Name, err)
+			failed++
+		}
+	}
+	logger.Infof("Done - %d changes failed", failed)
+}
+
+func makePlan(apps []Cx1ClientGo.Application, defs []AppDefinition, delete bool) []PlanItem {
+	plan := []PlanItem{}
+
+	for _, def := range defs {
+		desired := def.Desired()
+		i := slices.IndexFunc(apps, func(a Cx1ClientGo.Application) bool { return a.Name == def.Name })
+		if i < 0 {
+			plan = append(plan, PlanItem{Action: "create", Name: def.Name, Changes: diffApplication(&Cx1ClientGo.Application{}, &desired), Desired: desired})
+			continue
+		}
+
+		changes := diffApplication(&apps[i], &desired)
+		action := "update"
+		if len(changes) == 0 {
+			action = "unchanged"
+		}
+		plan = append(plan, PlanItem{Action: action, Name: def.Name, Changes: changes, Desired: desired, Current: &apps[i]})
+	}
+
+	for i := range apps {
+		if slices.ContainsFunc(defs, func(d AppDefinition) bool { return d.Name == apps[i].Name }) {
+			continue
+		}
+		if delete {
+			plan = append(plan, PlanItem{Action: "delete", Name: apps[i].Name, Current: &apps[i]})
+		} else {
+			plan = append(plan, PlanItem{Action: "unmanaged", Name: apps[i].Name, Changes: []string{"no YAML file - use -delete to remove it"}, Current: &apps[i]})
+		}
+	}
+
+	return plan
+}
+
+func diffApplication(current, desired *Cx1ClientGo.Application) []string {
+	changes := []string{}
+
+	if current.Description != desired.Description {
+		changes = append(changes, fmt.Sprintf("~ description: '%v' -> '%v'", current.Description, desired.Description))
+	}
+	if current.Criticality != desired.Criticality {
+		changes = append(changes, fmt.Sprintf("~ criticality: %d -> %d", current.Criticality, desired.Criticality))
+	}
+
+	for key, value := range current.Tags {
+		if newValue, ok := desired.Tags[key]; !ok {
+			changes = append(changes, fmt.Sprintf("- tag %v = %v", key, value))
+		} else if newValue != value {
+			changes = append(changes, fmt.Sprintf("~ tag %v: %v -> %v", key, value, newValue))
+		}
+	}
+	for key, value := range desired.Tags {
+		if _, ok := current.Tags[key]; !ok {
+			changes = append(changes, fmt.Sprintf("+ tag %v = %v", key, value))
+		}
+	}
+
+	currentRules := normalizeRules(current.Rules)
+	desiredRules := normalizeRules(desired.Rules)
+	for _, r := range currentRules {
+		if !slices.Contains(desiredRules, r) {
+			changes = append(changes, fmt.Sprintf("- rule %v", r))
+		}
+	}
+	for _, r := range desiredRules {
+		if !slices.Contains(currentRules, r) {
+			changes = append(changes, fmt.Sprintf("+ rule %v", r))
+		}
+	}
+
+	slices.Sort(changes)
+	return changes
+}
+
+func applyPlanItem(cx1client *Cx1ClientGo.Cx1Client, item PlanItem) error {
+	switch item.Action {
+	case "create":
+		app, err := cx1client.CreateApplication(item.Name)
+		if err != nil {
+			return err
+		}
+		logger.Infof("Created application %v", app.String())
+		item.Current = &app
+	case "update":
+	case "delete":
+		if err := cx1client.DeleteApplication(item.Current); err != nil {
+			return err
+		}
+		logger.Infof("Deleted application %v", item.Current.String())
+		return nil
+	default:
+		return nil
+	}
+
+	app := item.Current
+	app.Description = item.Desired.Description
+	app.Criticality = item.Desired.Criticality
+	app.Tags = item.Desired.Tags
+	app.Rules = item.Desired.Rules
+
+	if err := cx1client.UpdateApplication(app); err != nil {
+		return err
+	}
+	logger.Infof("Updated application %v", app.String())
+	return nil
+}
@@ -1,10 +1,9 @@
 package main
 
 import (
-	"crypto/tls"
+	"bufio"
 	"flag"
 	"net/http"
-	"net/url"
 	"os"
 	"slices"
 	"strings"
@@ -31,6 +30,11 @@ func main() {
 
 	DeleteOldRules := flag.Bool("delete", false, "Delete old-type rules")
 	Change := flag.Bool("update", false, "Make changes to project rules")
+	RunAll := flag.Bool("all", false, "With -update: apply the changes to all applications without asking to accept each preview")
+	SnapshotFile := flag.String("snapshot", "", "File to which the rules, project IDs and tags of all applications are saved before any change is made (default: app-rules-snapshot-<timestamp>.json)")
+	RestoreFile := flag.String("restore", "", "Restore the application description, criticality, rules and tags from this snapshot file instead of converting rules")
+	ReportFile := flag.String("report", "", "Write an application membership health report to this file (.csv or .json) instead of converting rules - no changes are made")
+	AppNames := flag.String("apps", "", "With -restore: optional comma-separated list of application names or IDs to restore, default all")
 
 	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
 	if err != nil {
@@ -62,69 +66,190 @@ func main() {
 		logger.Fatalf("Failed to get applications: %s", err)
 	}
 
+	if *ReportFile != "" {
+		report := healthReport(cx1client, apps, projects, logger)
+		if err = writeReport(report, *ReportFile); err != nil {
+			logger.Fatalf("Failed to write report to %v: %s", *ReportFile, err)
+		}
+		logger.Infof("Health report for %d applications and %d projects written to %v", len(apps), len(projects), *ReportFile)
+		return
+	}
+
+	if *Change {
+		logger.Warn("The 'update' flag is set - each application's changes will be previewed and applied once accepted")
+
+		if *SnapshotFile == "" {
+			*SnapshotFile = snapshotFilename()
+		}
+		if err = writeSnapshot(cx1client, apps, *SnapshotFile); err != nil {
+			logger.Fatalf("Failed to write snapshot of application rules to %v: %s", *SnapshotFile, err)
+		}
+		logger.Infof("Saved a snapshot of %d applications to %v - use -restore %v to undo the changes", len(apps), *SnapshotFile, *SnapshotFile)
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	if *RestoreFile != "" {
+		snapshot, err := readSnapshot(*RestoreFile)
+		if err != nil {
+			logger.Fatalf("Failed to read snapshot %v: %s", *RestoreFile, err)
+		}
+
+		names := []string{}
+		for _, name := range strings.Split(*AppNames, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+
+		restoreSnapshot(cx1client, apps, &projectsByID, snapshot, names, *Change, RunAll, logger)
+		return
+	}
+
 	for _, a := range apps {
-		processApp(cx1client, &a, &projectsByID, *DeleteOldRules, *Change, logger)
+		if processApp(cx1client, &a, projects, &projectsByID, *DeleteOldRules, *Change, RunAll, logger) {
+			break
+		}
+	}
+
+	if !*Change {
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
 	}
 }
 
-func processApp(cx1client *Cx1ClientGo.Cx1Client, app *Cx1ClientGo.Application, projectsByID *map[string]*Cx1ClientGo.Project, delete, update bool, logger *logrus.Logger) {
+// processApp evaluates every rule of the application against the full project list and previews the conversion to a single "project.name.in" rule.
+// The conversion is only applied with -update, after the preview has been accepted. Returns true if the user chose to quit.
+func processApp(cx1client *Cx1ClientGo.Cx1Client, app *Cx1ClientGo.Application, projects []Cx1ClientGo.Project, projectsByID *map[string]*Cx1ClientGo.Project, delete, update bool, runAll *bool, logger *logrus.Logger) bool {
 	projectNames := []string{}
-	extraProjects := []*Cx1ClientGo.Project{}
-	oldRules := false
+	matchedProjects := []*Cx1ClientGo.Project{}
+	oldRules := []Cx1ClientGo.ApplicationRule{}
+	unevaluated := false
 
 	logger.Infof("Processing application %v", app.String())
 
 	for _, r := range app.Rules {
 		if r.Type == "project.name.in" {
-			pns := strings.Split(r.Value, ";")
-			projectNames = append(projectNames, pns...)
-		} else {
-			oldRules = true
+			projectNames = append(projectNames, ruleValues(r.Value)...)
 		}
-	}
 
-	//logger.Infof(" - app has %d projects by name, and %d project IDs", len(projectNames), len(app.ProjectIds))
+		matches, err := evaluateRule(r, projects)
+		if err != nil {
+			logger.Warnf(" - rule %v with value %v cannot be evaluated locally: %s", r.Type, r.Value, err)
+			unevaluated = true
+			continue
+		}
+
+		if r.Type != "project.name.in" {
+			oldRules = append(oldRules, r)
+		}
+
+		logger.Infof(" - rule %v with value %v matches %d projects today", r.Type, r.Value, len(matches))
+		for _, p := range matches {
+			logger.Infof("    - %v", p.String())
+			if !slices.Contains(matchedProjects, p) {
+				matchedProjects = append(matchedProjects, p)
+			}
+		}
+	}
 
+	currentProjects := []*Cx1ClientGo.Project{}
 	for _, pid := range app.ProjectIds {
 		proj, ok := (*projectsByID)[pid]
 		if !ok {
 			logger.Errorf("Unknown project ID %v", pid)
+			continue
 		}
+		currentProjects = append(currentProjects, proj)
 
-		if !slices.Contains(projectNames, proj.Name) {
-			if !slices.Contains(extraProjects, proj) {
-				extraProjects = append(extraProjects, proj)
-			}
+		if !slices.Contains(matchedProjects, proj) {
+			logger.Warnf(" - project %v is currently in the application but is not matched by any rule", proj.String())
 		}
 	}
 
-	if len(extraProjects) > 0 || oldRules {
-		if len(extraProjects) > 0 {
-			//logger.Infof("Application %v has %d extra projects to add by name:", app.String(), len(extraProjects))
-			for _, p := range extraProjects {
-				app.AssignProject(p)
-				logger.Infof(" - to add project: %v", p.String())
-			}
+	for _, p := range matchedProjects {
+		if !slices.Contains(currentProjects, p) {
+			logger.Warnf(" - project %v is matched by the rules but is not currently in the application", p.String())
 		}
+	}
 
-		if oldRules {
-			for id, r := range app.Rules {
-				if r.Type != "project.name.in" {
-					logger.Infof(" - to remove old-style rule %v with value %v", r.Type, r.Value)
-					if delete {
-						app.RemoveRule(&app.Rules[id])
-					}
-				}
-			}
+	extraProjects := []*Cx1ClientGo.Project{}
+	for _, p := range append(matchedProjects, currentProjects...) {
+		if !slices.Contains(projectNames, p.Name) && !slices.Contains(extraProjects, p) {
+			extraProjects = append(extraProjects, p)
+		}
+	}
+
+	if len(extraProjects) == 0 && (len(oldRules) == 0 || !delete) {
+		logger.Infof(" - application %v requires no changes", app.String())
+		return false
+	}
+
+	for _, p := range extraProjects {
+		logger.Infof(" - to add project: %v", p.String())
+	}
+	if delete {
+		for _, r := range oldRules {
+			logger.Infof(" - to remove old-style rule %v with value %v", r.Type, r.Value)
 		}
+		if unevaluated {
+			logger.Warnf(" - rules that could not be evaluated will be kept")
+		}
+	}
 
-		if update {
-			if err := cx1client.UpdateApplication(app); err != nil {
-				logger.Errorf("Failed to update application %v: %s", app.String(), err)
-			} else {
-				logger.Infof("Application %v updated", app.String())
+	if !update {
+		return false
+	}
+
+	if apply, quit := confirmChanges(app.Name, runAll, logger); quit {
+		return true
+	} else if !apply {
+		logger.Infof("Skipping application %v", app.String())
+		return false
+	}
+
+	for _, p := range extraProjects {
+		app.AssignProject(p)
+	}
+
+	if delete {
+		for _, r := range oldRules {
+			if rule := app.GetRuleByType(r.Type); rule != nil && rule.Value == r.Value {
+				app.RemoveRule(rule)
 			}
 		}
 	}
 
+	if err := cx1client.UpdateApplication(app); err != nil {
+		logger.Errorf("Failed to update application %v: %s", app.String(), err)
+	} else {
+		logger.Infof("Application %v updated", app.String())
+	}
+	return false
+}
+
+// confirmChanges asks whether to apply the previewed changes to an application, unless -all was set
+func confirmChanges(appName string, runAll *bool, logger *logrus.Logger) (apply, quit bool) {
+	if *runAll {
+		return true, false
+	}
+
+	logger.Infof("Apply these changes to application %v? [yes/no/all/quit]: ", appName)
+	scanner := bufio.NewScanner(os.Stdin)
+	input := ""
+	if scanner.Scan() {
+		input = strings.ToLower(strings.TrimSpace(scanner.Text()))
+	}
+
+	switch input {
+	case "y", "yes":
+		return true, false
+	case "a", "all":
+		logger.Info("Applying changes for all subsequent applications.")
+		*runAll = true
+		return true, false
+	case "q", "quit":
+		logger.Info("Exiting.")
+		return false, true
+	}
+	return false, false
 }
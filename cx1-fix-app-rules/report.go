@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+)
+
+type Finding struct {
+	Category      string `json:"category"`
+	Application   string `json:"application,omitempty"`
+	ApplicationID string `json:"applicationId,omitempty"`
+	Project       string `json:"project,omitempty"`
+	ProjectID     string `json:"projectId,omitempty"`
+	Detail        string `json:"detail,omitempty"`
+}
+
+type AppHealth struct {
+	Application      string `json:"application"`
+	ApplicationID    string `json:"applicationId"`
+	Projects         int    `json:"projects"`
+	Rules            int    `json:"rules"`
+	StaleNames       int    `json:"staleProjectNames"`
+	DuplicateRules   int    `json:"duplicateRules"`
+	MultiAppProjects int    `json:"multiApplicationProjects"`
+}
+
+type HealthReport struct {
+	Tenant       string      `json:"tenant"`
+	Findings     []Finding   `json:"findings"`
+	Applications []AppHealth `json:"applications"`
+}
+
+// healthReport lists orphan projects, projects in several applications, project.name.in entries for projects that no longer exist,
+// empty applications and duplicate rules, with counts per application
+func healthReport(cx1client *Cx1ClientGo.Cx1Client, apps []Cx1ClientGo.Application, projects []Cx1ClientGo.Project, logger *logrus.Logger) HealthReport {
+	report := HealthReport{
+		Tenant:       cx1client.String(),
+		Findings:     []Finding{},
+		Applications: []AppHealth{},
+	}
+
+	projectNames := make(map[string]bool)
+	for _, p := range projects {
+		projectNames[p.Name] = true
+	}
+
+	appsByID := make(map[string]*Cx1ClientGo.Application)
+	for id := range apps {
+		appsByID[apps[id].ApplicationID] = &apps[id]
+	}
+
+	multiAppCount := make(map[string]int)
+	for _, p := range projects {
+		if len(p.Applications) == 0 {
+			report.Findings = append(report.Findings, Finding{Category: "orphan-project", Project: p.Name, ProjectID: p.ProjectID})
+		} else if len(p.Applications) > 1 {
+			names := []string{}
+			for _, id := range p.Applications {
+				if a, ok := appsByID[id]; ok {
+					names = append(names, a.Name)
+					multiAppCount[id]++
+				} else {
+					names = append(names, id)
+				}
+			}
+			report.Findings = append(report.Findings, Finding{Category: "multi-application-project", Project: p.Name, ProjectID: p.ProjectID, Detail: strings.Join(names, ";")})
+		}
+	}
+
+	for _, a := range apps {
+		health := AppHealth{
+			Application:      a.Name,
+			ApplicationID:    a.ApplicationID,
+			Projects:         len(a.ProjectIds),
+			Rules:            len(a.Rules),
+			MultiAppProjects: multiAppCount[a.ApplicationID],
+		}
+
+		if len(a.ProjectIds) == 0 {
+			report.Findings = append(report.Findings, Finding{Category: "empty-application", Application: a.Name, ApplicationID: a.ApplicationID, Detail: fmt.Sprintf("%d rules", len(a.Rules))})
+		}
+
+		seenRules := []Cx1ClientGo.ApplicationRule{}
+		for _, r := range a.Rules {
+			if slices.Contains(seenRules, r) {
+				health.DuplicateRules++
+				report.Findings = append(report.Findings, Finding{Category: "duplicate-rule", Application: a.Name, ApplicationID: a.ApplicationID, Detail: fmt.Sprintf("%v: %v", r.Type, r.Value)})
+				continue
+			}
+			seenRules = append(seenRules, r)
+
+			if r.Type != "project.name.in" {
+				continue
+			}
+
+			seenNames := []string{}
+			for _, name := range ruleValues(r.Value) {
+				if slices.Contains(seenNames, name) {
+					health.DuplicateRules++
+					report.Findings = append(report.Findings, Finding{Category: "duplicate-rule", Application: a.Name, ApplicationID: a.ApplicationID, Project: name, Detail: "project listed more than once in project.name.in"})
+					continue
+				}
+				seenNames = append(seenNames, name)
+
+				if !projectNames[name] {
+					health.StaleNames++
+					report.Findings = append(report.Findings, Finding{Category: "stale-project-name", Application: a.Name, ApplicationID: a.ApplicationID, Project: name, Detail: "no project with this name exists"})
+				}
+			}
+		}
+
+		report.Applications = append(report.Applications, health)
+	}
+
+	counts := make(map[string]int)
+	for _, f := range report.Findings {
+		counts[f.Category]++
+	}
+	logger.Infof("Health report: %d orphan projects, %d multi-application projects, %d stale project names, %d empty applications, %d duplicate rules",
+		counts["orphan-project"], counts["multi-application-project"], counts["stale-project-name"], counts["empty-application"], counts["duplicate-rule"])
+
+	return report
+}
+
+// writeReport writes the report as JSON, or as CSV if the file name ends with .csv - in that case the per-application counts go to a second file named <file>_counts.csv
+func writeReport(report HealthReport, filename string) error {
+	if !strings.EqualFold(filepath.Ext(filename), ".csv") {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filename, data, 0644)
+	}
+
+	findings := [][]string{{"Category", "Application", "ApplicationID", "Project", "ProjectID", "Detail"}}
+	for _, f := range report.Findings {
+		findings = append(findings, []string{f.Category, f.Application, f.ApplicationID, f.Project, f.ProjectID, f.Detail})
+	}
+	if err := writeCSV(filename, findings); err != nil {
+		return err
+	}
+
+	counts := [][]string{{"Application", "ApplicationID", "Projects", "Rules", "StaleProjectNames", "DuplicateRules", "MultiApplicationProjects"}}
+	for _, a := range report.Applications {
+		counts = append(counts, []string{a.Application, a.ApplicationID, fmt.Sprint(a.Projects), fmt.Sprint(a.Rules), fmt.Sprint(a.StaleNames), fmt.Sprint(a.DuplicateRules), fmt.Sprint(a.MultiAppProjects)})
+	}
+	return writeCSV(strings.TrimSuffix(filename, filepath.Ext(filename))+"_counts.csv", counts)
+}
+
+func writeCSV(filename string, records [][]string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// ruleValues splits a multi-value rule (eg: "proj1;proj2") into its values
+func ruleValues(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// ruleKeyValues parses a project.tag.key-value.exists rule, which is either a list of "key:value" pairs or a single "key;value" pair
+func ruleKeyValues(value string) [][2]string {
+	pairs := [][2]string{}
+	if strings.Contains(value, ":") {
+		for _, v := range ruleValues(value) {
+			key, val, _ := strings.Cut(v, ":")
+			pairs = append(pairs, [2]string{strings.TrimSpace(key), strings.TrimSpace(val)})
+		}
+	} else if key, val, ok := strings.Cut(value, ";"); ok {
+		pairs = append(pairs, [2]string{strings.TrimSpace(key), strings.TrimSpace(val)})
+	}
+	return pairs
+}
+
+// evaluateRule runs one application rule locally against the full project list and returns the projects it matches.
+// An error is returned for rule types that cannot be evaluated.
+func evaluateRule(rule Cx1ClientGo.ApplicationRule, projects []Cx1ClientGo.Project) ([]*Cx1ClientGo.Project, error) {
+	var match func(p *Cx1ClientGo.Project) bool
+	values := ruleValues(rule.Value)
+
+	switch rule.Type {
+	case "project.name.in":
+		match = func(p *Cx1ClientGo.Project) bool { return slices.Contains(values, p.Name) }
+	case "project.name.contains":
+		match = func(p *Cx1ClientGo.Project) bool {
+			return slices.ContainsFunc(values, func(v string) bool { return strings.Contains(p.Name, v) })
+		}
+	case "project.name.starts-with":
+		match = func(p *Cx1ClientGo.Project) bool {
+			return slices.ContainsFunc(values, func(v string) bool { return strings.HasPrefix(p.Name, v) })
+		}
+	case "project.name.regex":
+		re, err := regexp.Compile(rule.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %s", err)
+		}
+		match = func(p *Cx1ClientGo.Project) bool { return re.MatchString(p.Name) }
+	case "project.tag.key.exists":
+		match = func(p *Cx1ClientGo.Project) bool {
+			return slices.ContainsFunc(values, func(v string) bool { _, ok := p.Tags[v]; return ok })
+		}
+	case "project.tag.value.exists":
+		match = func(p *Cx1ClientGo.Project) bool {
+			for _, tv := range p.Tags {
+				if slices.Contains(values, tv) {
+					return true
+				}
+			}
+			return false
+		}
+	case "project.tag.key-value.exists":
+		pairs := ruleKeyValues(rule.Value)
+		if len(pairs) == 0 {
+			return nil, fmt.Errorf("unable to parse key-value pair from '%v'", rule.Value)
+		}
+		match = func(p *Cx1ClientGo.Project) bool {
+			return slices.ContainsFunc(pairs, func(kv [2]string) bool { tv, ok := p.Tags[kv[0]]; return ok && tv == kv[1] })
+		}
+	default:
+		return nil, fmt.Errorf("unknown rule type")
+	}
+
+	matches := []*Cx1ClientGo.Project{}
+	for id := range projects {
+		if match(&projects[id]) {
+			matches = append(matches, &projects[id])
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+)
+
+type AppSnapshot struct {
+	ApplicationID string                        `json:"id"`
+	Name          string                        `json:"name"`
+	Description   string                        `json:"description"`
+	Criticality   uint                          `json:"criticality"`
+	Rules         []Cx1ClientGo.ApplicationRule `json:"rules"`
+	ProjectIds    []string                      `json:"projectIds"`
+	Tags          map[string]string             `json:"tags"`
+}
+
+type Snapshot struct {
+	Timestamp    string        `json:"timestamp"`
+	Tenant       string        `json:"tenant"`
+	Applications []AppSnapshot `json:"applications"`
+}
+
+func snapshotFilename() string {
+	return fmt.Sprintf("app-rules-snapshot-%v.json", time.Now().Format("20060102-150405"))
+}
+
+// writeSnapshot saves the rules, project IDs and tags of every application so that they can be put back with -restore
+func writeSnapshot(cx1client *Cx1ClientGo.Cx1Client, apps []Cx1ClientGo.Application, filename string) error {
+	snapshot := Snapshot{
+		Timestamp:    time.Now().Format(time.RFC3339),
+		Tenant:       cx1client.String(),
+		Applications: []AppSnapshot{},
+	}
+
+	for _, a := range apps {
+		snapshot.Applications = append(snapshot.Applications, AppSnapshot{
+			ApplicationID: a.ApplicationID,
+			Name:          a.Name,
+			Description:   a.Description,
+			Criticality:   a.Criticality,
+			Rules:         a.Rules,
+			ProjectIds:    a.ProjectIds,
+			Tags:          a.Tags,
+		})
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+func readSnapshot(filename string) (Snapshot, error) {
+	var snapshot Snapshot
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return snapshot, err
+	}
+	err = json.Unmarshal(data, &snapshot)
+	return snapshot, err
+}
+
+// restoreSnapshot puts the description, criticality, rules and tags from the snapshot back onto the applications, after printing a diff against the current state.
+// Applications are matched by ID, or by name if they were re-created. Applications that no longer exist are created.
+func restoreSnapshot(cx1client *Cx1ClientGo.Cx1Client, apps []Cx1ClientGo.Application, projectsByID *map[string]*Cx1ClientGo.Project, snapshot Snapshot, appNames []string, update bool, runAll *bool, logger *logrus.Logger) {
+	logger.Infof("Restoring snapshot from %v taken on %v", snapshot.Timestamp, snapshot.Tenant)
+
+	restored, unchanged, failed := 0, 0, 0
+	for _, saved := range snapshot.Applications {
+		if len(appNames) > 0 && !slices.Contains(appNames, saved.Name) && !slices.Contains(appNames, saved.ApplicationID) {
+			continue
+		}
+
+		var app *Cx1ClientGo.Application
+		for id := range apps {
+			if apps[id].ApplicationID == saved.ApplicationID {
+				app = &apps[id]
+				break
+			}
+		}
+		if app == nil {
+			for id := range apps {
+				if apps[id].Name == saved.Name {
+					app = &apps[id]
+					break
+				}
+			}
+		}
+
+		if app == nil {
+			logger.Infof("Application %v no longer exists and will be re-created with %d rules and %d tags", saved.Name, len(saved.Rules), len(saved.Tags))
+		} else {
+			logger.Infof("Comparing application %v with the snapshot", app.String())
+			if !diffApplication(app, saved, projectsByID, logger) {
+				logger.Infof(" - application %v matches the snapshot", app.String())
+				unchanged++
+				continue
+			}
+		}
+
+		if !update {
+			continue
+		}
+
+		apply, quit := confirmChanges(saved.Name, runAll, logger)
+		if quit {
+			break
+		} else if !apply {
+			continue
+		}
+
+		if app == nil {
+			newApp, err := cx1client.CreateApplication(saved.Name)
+			if err != nil {
+				logger.Errorf("Failed to re-create application %v: %s", saved.Name, err)
+				failed++
+				continue
+			}
+			app = &newApp
+		}
+
+		app.Description = saved.Description
+		app.Criticality = saved.Criticality
+		app.Rules = saved.Rules
+		app.Tags = saved.Tags
+		if err := cx1client.UpdateApplication(app); err != nil {
+			logger.Errorf("Failed to restore application %v: %s", app.String(), err)
+			failed++
+		} else {
+			logger.Infof("Application %v restored", app.String())
+			restored++
+		}
+	}
+
+	if update {
+		logger.Infof("Restore finished - %d applications restored, %d already matched the snapshot, %d failed", restored, unchanged, failed)
+	} else {
+		logger.Infof("Restore preview finished - %d applications already match the snapshot", unchanged)
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+	}
+}
+
+// diffApplication logs the differences between the current application and the snapshot, and returns true if there are any
+func diffApplication(app *Cx1ClientGo.Application, saved AppSnapshot, projectsByID *map[string]*Cx1ClientGo.Project, logger *logrus.Logger) bool {
+	changed := false
+
+	if app.Description != saved.Description {
+		logger.Infof(" ~ description will be changed from '%v' to '%v'", app.Description, saved.Description)
+		changed = true
+	}
+	if app.Criticality != saved.Criticality {
+		logger.Infof(" ~ criticality will be changed from %d to %d", app.Criticality, saved.Criticality)
+		changed = true
+	}
+
+	for _, r := range app.Rules {
+		if !slices.Contains(saved.Rules, r) {
+			logger.Infof(" - rule %v with value %v will be removed", r.Type, r.Value)
+			changed = true
+		}
+	}
+	for _, r := range saved.Rules {
+		if !slices.Contains(app.Rules, r) {
+			logger.Infof(" + rule %v with value %v will be restored", r.Type, r.Value)
+			changed = true
+		}
+	}
+
+	for key, value := range app.Tags {
+		if savedValue, ok := saved.Tags[key]; !ok {
+			logger.Infof(" - tag %v = %v will be removed", key, value)
+			changed = true
+		} else if savedValue != value {
+			logger.Infof(" ~ tag %v will be changed from %v to %v", key, value, savedValue)
+			changed = true
+		}
+	}
+	for key, value := range saved.Tags {
+		if _, ok := app.Tags[key]; !ok {
+			logger.Infof(" + tag %v = %v will be restored", key, value)
+			changed = true
+		}
+	}
+
+	projectName := func(id string) string {
+		if p, ok := (*projectsByID)[id]; ok {
+			return p.String()
+		}
+		return fmt.Sprintf("[%v] (deleted project)", id)
+	}
+	for _, id := range app.ProjectIds {
+		if !slices.Contains(saved.ProjectIds, id) {
+			logger.Infof("   project %v is in the application now but was not in the snapshot", projectName(id))
+		}
+	}
+	for _, id := range saved.ProjectIds {
+		if !slices.Contains(app.ProjectIds, id) {
+			logger.Infof("   project %v was in the application in the snapshot but is not now", projectName(id))
+		}
+	}
+
+	return changed
+}
@@ -7,12 +7,20 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/cxpsemea/Cx1ClientGo"
 	"github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
 
+type TreeShape struct {
+	Depth    int
+	Breadth  int
+	Children []string
+}
+
 func main() {
 	logger := logrus.New()
 	logger.SetLevel(logrus.TraceLevel)
@@ -22,8 +30,18 @@ func main() {
 	logger.SetFormatter(myformatter)
 	logger.SetOutput(os.Stdout)
 
-	DeleteGroups := flag.Bool("delete", false, "Toggle to delete all previously-created groups")
-	NumberOfGroups := flag.Int("count", 100, "Number of groups to create")
+	LogLevel := flag.String("log", "TRACE", "Log level: TRACE, DEBUG, INFO, WARNING, ERROR, FATAL")
+	DeleteGroups := flag.Bool("delete", false, "Toggle to delete all previously-created groups, and with -projects also the previously-created projects")
+	NumberOfGroups := flag.Int("count", 100, "Number of top-level groups to create")
+	GroupPrefix := flag.String("prefix", "testgroup-", "Name prefix of the generated top-level groups")
+	Depth := flag.Int("depth", 1, "Number of levels of child groups below each top-level group")
+	Breadth := flag.Int("breadth", 0, "Number of child groups per group at each level, 0 to use the number of -children names")
+	ChildNames := flag.String("children", "Owners,Scanners,Reviewers,Readers,Service Accounts", "Comma-separated child group names, extra children beyond this list are named child-NN")
+	Concurrency := flag.Int("concurrency", 1, "Number of parallel workers")
+	NumberOfProjects := flag.Int("projects", 0, "Number of projects to create, each assigned to all groups of one generated group tree (round-robin). With -delete, any value above 0 deletes the projects named -project-prefix*")
+	ProjectPrefix := flag.String("project-prefix", "testproject-", "Name prefix of the generated projects")
+	ListIterations := flag.Int("list", 5, "Number of times to list all groups and search groups by name after creation")
+	SummaryFile := flag.String("summary", "groups-load-summary.csv", "File for the latency & error summary per operation (.csv or .json)")
 
 	logger.Info("Starting")
 	httpClient := &http.Client{}
@@ -33,56 +51,230 @@ func main() {
 		logger.Fatalf("Error creating client: %s", err)
 	}
 
+	switch strings.ToUpper(*LogLevel) {
+	case "TRACE":
+		logger.SetLevel(logrus.TraceLevel)
+	case "DEBUG":
+		logger.SetLevel(logrus.DebugLevel)
+	case "INFO":
+		logger.SetLevel(logrus.InfoLevel)
+	case "WARNING":
+		logger.SetLevel(logrus.WarnLevel)
+	case "ERROR":
+		logger.SetLevel(logrus.ErrorLevel)
+	case "FATAL":
+		logger.SetLevel(logrus.FatalLevel)
+	default:
+		logger.Fatalf("Invalid log level %v", *LogLevel)
+	}
+
 	logger.Infof("Connected with %v", cx1client.String())
 
-	if *DeleteGroups {
-		groups, err := cx1client.GetGroupsByName("testgroup-")
-		if err != nil {
-			logger.Fatalf("Failed to get groups: %s", err)
-		}
-		for _, g := range groups {
-			err := cx1client.DeleteGroup(&g)
-			if err != nil {
-				logger.Errorf("Failed to delete group %v: %s", g.String(), err)
-			} else {
-				logger.Infof("Deleted group %v", g.String())
-			}
+	if *Concurrency < 1 {
+		*Concurrency = 1
+	}
+
+	shape := TreeShape{Depth: *Depth, Breadth: *Breadth}
+	for _, name := range strings.Split(*ChildNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			shape.Children = append(shape.Children, name)
 		}
+	}
+	if shape.Breadth == 0 {
+		shape.Breadth = len(shape.Children)
+	}
+
+	recorder := NewRecorder()
+
+	if *DeleteGroups {
+		deleteAll(cx1client, recorder, *GroupPrefix, *ProjectPrefix, *NumberOfProjects > 0, *Concurrency, logger)
 	} else {
-		for i := 1; i <= *NumberOfGroups; i++ {
-			logger.Infof("Creating group batch %d", i)
-			err := createGroups(cx1client, i)
+		logger.Infof("Creating %d group trees with depth %d and breadth %d (%d groups each) using %d workers", *NumberOfGroups, shape.Depth, shape.Breadth, shape.GroupCount(), *Concurrency)
+
+		treeGroupIDs := make([][]string, *NumberOfGroups)
+		runConcurrently(*NumberOfGroups, *Concurrency, func(i int) {
+			logger.Infof("Creating group batch %d", i+1)
+			ids, err := createGroups(cx1client, recorder, shape, fmt.Sprintf("%v%04d", *GroupPrefix, i+1))
 			if err != nil {
-				logger.Errorf("Failed while creating group batch %d: %s", i, err)
+				logger.Errorf("Failed while creating group batch %d: %s", i+1, err)
 			}
+			treeGroupIDs[i] = ids
+		})
+
+		if *NumberOfProjects > 0 && *NumberOfGroups > 0 {
+			logger.Infof("Creating %d projects", *NumberOfProjects)
+			runConcurrently(*NumberOfProjects, *Concurrency, func(i int) {
+				name := fmt.Sprintf("%v%04d", *ProjectPrefix, i+1)
+				groupIDs := treeGroupIDs[i%*NumberOfGroups]
+				err := recorder.Time("create-project", func() error {
+					_, err := cx1client.CreateProject(name, groupIDs, map[string]string{})
+					return err
+				})
+				if err != nil {
+					logger.Errorf("Failed to create project %v with %d groups: %s", name, len(groupIDs), err)
+				} else {
+					logger.Infof("Created project %v with %d groups", name, len(groupIDs))
+				}
+			})
 		}
+
+		logger.Infof("Listing and searching groups %d times", *ListIterations)
+		runConcurrently(*ListIterations, *Concurrency, func(i int) {
+			if err := recorder.Time("list", func() error {
+				_, err := cx1client.GetGroups()
+				return err
+			}); err != nil {
+				logger.Errorf("Failed to list groups: %s", err)
+			}
+
+			if err := recorder.Time("GetGroupsByName", func() error {
+				_, err := cx1client.GetGroupsByName(*GroupPrefix)
+				return err
+			}); err != nil {
+				logger.Errorf("Failed to get groups by name %v: %s", *GroupPrefix, err)
+			}
+		})
+	}
+
+	summary := recorder.Summary()
+	for _, s := range summary {
+		logger.Infof("%-16v calls: %5d errors: %4d (%5.1f%%)  p50: %8.1fms  p95: %8.1fms  p99: %8.1fms  max: %8.1fms",
+			s.Operation, s.Calls, s.Errors, s.ErrorRate*100, s.P50Ms, s.P95Ms, s.P99Ms, s.MaxMs)
+	}
+	if err = WriteSummary(summary, *SummaryFile); err != nil {
+		logger.Errorf("Failed to write summary to %v: %s", *SummaryFile, err)
+	} else {
+		logger.Infof("Summary written to %v", *SummaryFile)
 	}
 
 	logger.Infof("Done!")
 }
 
-func createGroups(cx1client *Cx1ClientGo.Cx1Client, id int) error {
-	groupName := fmt.Sprintf("testgroup-%04d", id)
-	group, err := cx1client.CreateGroup(groupName)
+// runConcurrently calls work(0) ... work(count-1) spread over the given number of workers
+func runConcurrently(count, workers int, work func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
 
-	if err != nil {
-		return err
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				work(i)
+			}
+		}()
 	}
 
-	if _, err = cx1client.CreateChildGroup(&group, "Owners"); err != nil {
-		return err
+	for i := 0; i < count; i++ {
+		jobs <- i
 	}
-	if _, err = cx1client.CreateChildGroup(&group, "Scanners"); err != nil {
-		return err
+	close(jobs)
+	wg.Wait()
+}
+
+// GroupCount is the number of groups in one tree, including the top-level group
+func (s TreeShape) GroupCount() int {
+	count, level := 1, 1
+	for d := 0; d < s.Depth; d++ {
+		level *= s.Breadth
+		count += level
 	}
-	if _, err = cx1client.CreateChildGroup(&group, "Reviewers"); err != nil {
-		return err
+	return count
+}
+
+func (s TreeShape) ChildName(i int) string {
+	if i < len(s.Children) {
+		return s.Children[i]
 	}
-	if _, err = cx1client.CreateChildGroup(&group, "Readers"); err != nil {
+	return fmt.Sprintf("child-%02d", i+1)
+}
+
+// createGroups creates one top-level group with its tree of child groups, and returns the IDs of all groups created
+func createGroups(cx1client *Cx1ClientGo.Cx1Client, recorder *Recorder, shape TreeShape, groupName string) ([]string, error) {
+	var group Cx1ClientGo.Group
+	err := recorder.Time("create", func() error {
+		var err error
+		group, err = cx1client.CreateGroup(groupName)
 		return err
+	})
+	if err != nil {
+		return []string{}, err
 	}
-	if _, err = cx1client.CreateChildGroup(&group, "Service Accounts"); err != nil {
-		return err
+
+	groupIDs := []string{group.GroupID}
+	err = createChildGroups(cx1client, recorder, shape, &group, 1, &groupIDs)
+	return groupIDs, err
+}
+
+func createChildGroups(cx1client *Cx1ClientGo.Cx1Client, recorder *Recorder, shape TreeShape, parent *Cx1ClientGo.Group, level int, groupIDs *[]string) error {
+	if level > shape.Depth {
+		return nil
+	}
+
+	for i := 0; i < shape.Breadth; i++ {
+		var child Cx1ClientGo.Group
+		err := recorder.Time("create", func() error {
+			var err error
+			child, err = cx1client.CreateChildGroup(parent, shape.ChildName(i))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		*groupIDs = append(*groupIDs, child.GroupID)
+
+		if err = createChildGroups(cx1client, recorder, shape, &child, level+1, groupIDs); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// deleteAll deletes the groups named groupPrefix*, and the projects named projectPrefix* only if deleteProjects is set
+func deleteAll(cx1client *Cx1ClientGo.Cx1Client, recorder *Recorder, groupPrefix, projectPrefix string, deleteProjects bool, concurrency int, logger *logrus.Logger) {
+	var projects []Cx1ClientGo.Project
+	if deleteProjects {
+		err := recorder.Time("list-projects", func() error {
+			var err error
+			projects, err = cx1client.GetProjectsByName(projectPrefix)
+			return err
+		})
+		if err != nil {
+			logger.Errorf("Failed to get projects: %s", err)
+		}
+	}
+
+	runConcurrently(len(projects), concurrency, func(i int) {
+		p := projects[i]
+		if !strings.HasPrefix(p.Name, projectPrefix) {
+			return
+		}
+		if err := recorder.Time("delete-project", func() error { return cx1client.DeleteProject(&p) }); err != nil {
+			logger.Errorf("Failed to delete project %v: %s", p.String(), err)
+		} else {
+			logger.Infof("Deleted project %v", p.String())
+		}
+	})
+
+	var groups []Cx1ClientGo.Group
+	err := recorder.Time("GetGroupsByName", func() error {
+		var err error
+		groups, err = cx1client.GetGroupsByName(groupPrefix)
+		return err
+	})
+	if err != nil {
+		logger.Fatalf("Failed to get groups: %s", err)
+	}
+
+	runConcurrently(len(groups), concurrency, func(i int) {
+		g := groups[i]
+		if !strings.HasPrefix(g.Name, groupPrefix) {
+			return
+		}
+		if err := recorder.Time("delete", func() error { return cx1client.DeleteGroup(&g) }); err != nil {
+			logger.Errorf("Failed to delete group %v: %s", g.String(), err)
+		} else {
+			logger.Infof("Deleted group %v", g.String())
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Recorder collects the latency and outcome of every API call, per operation, and is safe for concurrent use
+type Recorder struct {
+	mutex     sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	order     []string
+}
+
+type OperationSummary struct {
+	Operation string  `json:"operation"`
+	Calls     int     `json:"calls"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"errorRate"`
+	MinMs     float64 `json:"minMs"`
+	P50Ms     float64 `json:"p50Ms"`
+	P95Ms     float64 `json:"p95Ms"`
+	P99Ms     float64 `json:"p99Ms"`
+	MaxMs     float64 `json:"maxMs"`
+	MeanMs    float64 `json:"meanMs"`
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+	}
+}
+
+// Time runs the call and records its duration under the operation name, returning the call's error
+func (r *Recorder) Time(operation string, call func() error) error {
+	start := time.Now()
+	err := call()
+	elapsed := time.Since(start)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.latencies[operation]; !ok {
+		r.order = append(r.order, operation)
+	}
+	r.latencies[operation] = append(r.latencies[operation], elapsed)
+	if err != nil {
+		r.errors[operation]++
+	}
+	return err
+}
+
+func (r *Recorder) Summary() []OperationSummary {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	summary := []OperationSummary{}
+	for _, op := range r.order {
+		latencies := slices.Clone(r.latencies[op])
+		slices.Sort(latencies)
+
+		var total time.Duration
+		for _, l := range latencies {
+			total += l
+		}
+
+		calls := len(latencies)
+		summary = append(summary, OperationSummary{
+			Operation: op,
+			Calls:     calls,
+			Errors:    r.errors[op],
+			ErrorRate: float64(r.errors[op]) / float64(calls),
+			MinMs:     ms(latencies[0]),
+			P50Ms:     ms(percentile(latencies, 50)),
+			P95Ms:     ms(percentile(latencies, 95)),
+			P99Ms:     ms(percentile(latencies, 99)),
+			MaxMs:     ms(latencies[calls-1]),
+			MeanMs:    ms(total / time.Duration(calls)),
+		})
+	}
+	return summary
+}
+
+// percentile uses the nearest-rank method on a sorted list
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// WriteSummary writes the summary as CSV, or as JSON if the file name ends with .json
+func WriteSummary(summary []OperationSummary, filename string) error {
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filename, data, 0644)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"Operation", "Calls", "Errors", "ErrorRate", "MinMs", "P50Ms", "P95Ms", "P99Ms", "MaxMs", "MeanMs"})
+	for _, s := range summary {
+		writer.Write([]string{s.Operation, fmt.Sprint(s.Calls), fmt.Sprint(s.Errors), fmt.Sprintf("%.4f", s.ErrorRate),
+			fmt.Sprintf("%.1f", s.MinMs), fmt.Sprintf("%.1f", s.P50Ms), fmt.Sprintf("%.1f", s.P95Ms), fmt.Sprintf("%.1f", s.P99Ms), fmt.Sprintf("%.1f", s.MaxMs), fmt.Sprintf("%.1f", s.MeanMs)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TagCount counts how many projects and applications use a tag key or value
+type TagCount struct {
+	Projects     int
+	Applications int
+}
+
+func (c TagCount) Total() int {
+	return c.Projects + c.Applications
+}
+
+func (c TagCount) String() string {
+	return fmt.Sprintf("%d projects, %d applications", c.Projects, c.Applications)
+}
+
+type KeyStats struct {
+	Key    string
+	Count  TagCount
+	Values map[string]*TagCount
+}
+
+// Inventory holds every tag key and value found on projects and applications
+type Inventory struct {
+	Keys map[string]*KeyStats
+}
+
+// VariantGroup is a set of spellings that normalize to the same form, eg: 'App', 'app' and 'APP '
+type VariantGroup struct {
+	Normalized string
+	Variants   []string // most used first
+	Counts     map[string]TagCount
+}
+
+func NewInventory() *Inventory {
+	return &Inventory{Keys: make(map[string]*KeyStats)}
+}
+
+func (inv *Inventory) Add(tags map[string]string, isProject bool) {
+	for key, value := range tags {
+		stats, ok := inv.Keys[key]
+		if !ok {
+			stats = &KeyStats{Key: key, Values: make(map[string]*TagCount)}
+			inv.Keys[key] = stats
+		}
+		valueCount, ok := stats.Values[value]
+		if !ok {
+			valueCount = &TagCount{}
+			stats.Values[value] = valueCount
+		}
+
+		if isProject {
+			stats.Count.Projects++
+			valueCount.Projects++
+		} else {
+			stats.Count.Applications++
+			valueCount.Applications++
+		}
+	}
+}
+
+// SortedKeys returns the keys by descending use, then by name
+func (inv *Inventory) SortedKeys() []string {
+	keys := []string{}
+	for key := range inv.Keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := inv.Keys[keys[i]].Count.Total(), inv.Keys[keys[j]].Count.Total()
+		if a != b {
+			return a > b
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// KeyGroups returns the groups of near-duplicate keys, only those with more than one spelling
+func (inv *Inventory) KeyGroups(n Normalizer) []VariantGroup {
+	counts := make(map[string]TagCount)
+	for key, stats := range inv.Keys {
+		counts[key] = stats.Count
+	}
+	return groupVariants(counts, n)
+}
+
+// ValueGroups returns the groups of near-duplicate values across all spellings of the given keys
+func (inv *Inventory) ValueGroups(keys []string, n Normalizer) []VariantGroup {
+	counts := make(map[string]TagCount)
+	for _, key := range keys {
+		for value, c := range inv.Keys[key].Values {
+			total := counts[value]
+			total.Projects += c.Projects
+			total.Applications += c.Applications
+			counts[value] = total
+		}
+	}
+	return groupVariants(counts, n)
+}
+
+func groupVariants(counts map[string]TagCount, n Normalizer) []VariantGroup {
+	byNorm := make(map[string]*VariantGroup)
+	for s, c := range counts {
+		norm := n.Normalize(s)
+		g, ok := byNorm[norm]
+		if !ok {
+			g = &VariantGroup{Normalized: norm, Counts: make(map[string]TagCount)}
+			byNorm[norm] = g
+		}
+		g.Variants = append(g.Variants, s)
+		g.Counts[s] = c
+	}
+
+	groups := []VariantGroup{}
+	for _, g := range byNorm {
+		if len(g.Variants) < 2 {
+			continue
+		}
+		sort.Slice(g.Variants, func(i, j int) bool {
+			a, b := g.Counts[g.Variants[i]].Total(), g.Counts[g.Variants[j]].Total()
+			if a != b {
+				return a > b
+			}
+			return g.Variants[i] < g.Variants[j]
+		})
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Normalized < groups[j].Normalized })
+	return groups
+}
+
+// Canonical is the suggested spelling for the group: the most used variant, without surrounding whitespace
+func (g VariantGroup) Canonical() string {
+	return strings.TrimSpace(g.Variants[0])
+}
+
+// Suggest builds a mapping that rewrites every near-duplicate key and value to its most used spelling
+func (inv *Inventory) Suggest(n Normalizer, synonyms map[string][]string) Mapping {
+	m := Mapping{
+		Synonyms: synonyms,
+		Keys:     make(map[string][]string),
+		Values:   make(map[string]map[string][]string),
+	}
+
+	// keys that are not near-duplicates of another key may still have surrounding whitespace
+	grouped := make(map[string]string)
+	for _, g := range inv.KeyGroups(n) {
+		for _, key := range g.Variants {
+			grouped[key] = g.Canonical()
+		}
+	}
+	for key := range inv.Keys {
+		if _, ok := grouped[key]; !ok {
+			grouped[key] = strings.TrimSpace(key)
+		}
+	}
+
+	canonicalKeys := make(map[string][]string) // canonical key -> all spellings
+	for key, canonical := range grouped {
+		canonicalKeys[canonical] = append(canonicalKeys[canonical], key)
+		if key != canonical {
+			m.Keys[canonical] = append(m.Keys[canonical], key)
+		}
+	}
+
+	for canonical, keys := range canonicalKeys {
+		values := make(map[string]string) // spelling -> canonical value
+		for _, g := range inv.ValueGroups(keys, n) {
+			for _, value := range g.Variants {
+				values[value] = g.Canonical()
+			}
+		}
+		for _, key := range keys {
+			for value := range inv.Keys[key].Values {
+				if _, ok := values[value]; !ok {
+					values[value] = strings.TrimSpace(value)
+				}
+			}
+		}
+
+		for value, canonicalValue := range values {
+			if value == canonicalValue {
+				continue
+			}
+			if m.Values[canonical] == nil {
+				m.Values[canonical] = make(map[string][]string)
+			}
+			if !slices.Contains(m.Values[canonical][canonicalValue], value) {
+				m.Values[canonical][canonicalValue] = append(m.Values[canonical][canonicalValue], value)
+			}
+		}
+	}
+
+	for _, variants := range m.Keys {
+		sort.Strings(variants)
+	}
+	for _, values := range m.Values {
+		for _, variants := range values {
+			sort.Strings(variants)
+		}
+	}
+	return m
+}
+
+// WriteCSV writes one row per key and value with their counts
+func (inv *Inventory) WriteCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err = w.Write([]string{"key", "value", "projects", "applications"}); err != nil {
+		return err
+	}
+	for _, key := range inv.SortedKeys() {
+		stats := inv.Keys[key]
+		values := []string{}
+		for value := range stats.Values {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			c := stats.Values[value]
+			if err = w.Write([]string{key, value, strconv.Itoa(c.Projects), strconv.Itoa(c.Applications)}); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/bulk"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+var logger *logrus.Logger
+
+func main() {
+	logger = logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	logger.Info("Starting")
+	logger.Info("The purpose of this tool is to find tag keys and values that differ only by case, whitespace or synonyms, and to rewrite them to one canonical spelling.")
+
+	LogLevel := flag.String("log", "INFO", "Log level: TRACE, DEBUG, INFO, WARNING, ERROR, FATAL - tag values are listed at DEBUG")
+	EntityType := flag.String("type", "all", "Which tags to check: project, application or all")
+	MappingFile := flag.String("mapping", "", "Optional: YAML file with synonyms for grouping, and the canonical keys and values for -apply")
+	ReportFile := flag.String("report", "", "Optional: write every tag key and value with counts to this CSV file")
+	SuggestFile := flag.String("suggest", "", "Optional: write a mapping that rewrites each group of near-duplicates to its most used spelling to this YAML file, for review before -apply")
+	Apply := flag.Bool("apply", false, "Rewrite the tag keys and values according to the -mapping file")
+	Update := flag.Bool("update", false, "Apply the rewrite, otherwise only preview it")
+	Delay := flag.Int("delay", 1000, "Delay in milliseconds between updates")
+	RevertLogFile := flag.String("revert-log", "", "File to record each project's tags before they are rewritten (default: tag-normalize-revert-<timestamp>.jsonl)")
+	RevertFile := flag.String("revert", "", "Revert mode: put back the project tags recorded in this revert file, skipping projects changed since. The other flags except -update are ignored in this mode.")
+	RevertKeys := flag.String("revert-keys", "", "Revert mode: optional comma-separated list of tag keys to revert, other keys are left as they are")
+
+	httpClient := &http.Client{}
+	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
+	if err != nil {
+		logger.Fatalf("Error creating client: %s", err)
+	}
+	logger.Infof("Connected with %v", cx1client.String())
+
+	switch strings.ToUpper(*LogLevel) {
+	case "TRACE":
+		logger.Info("Setting log level to TRACE")
+		logger.SetLevel(logrus.TraceLevel)
+	case "DEBUG":
+		logger.Info("Setting log level to DEBUG")
+		logger.SetLevel(logrus.DebugLevel)
+	case "INFO":
+		logger.Info("Setting log level to INFO")
+		logger.SetLevel(logrus.InfoLevel)
+	case "WARNING":
+		logger.Info("Setting log level to WARNING")
+		logger.SetLevel(logrus.WarnLevel)
+	case "ERROR":
+		logger.Info("Setting log level to ERROR")
+		logger.SetLevel(logrus.ErrorLevel)
+	case "FATAL":
+		logger.Info("Setting log level to FATAL")
+		logger.SetLevel(logrus.FatalLevel)
+	default:
+		logger.Info("Log level set to default: INFO")
+	}
+
+	if *RevertFile != "" {
+		keys := []string{}
+		for _, key := range strings.Split(*RevertKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		bulk.RevertTags(projectTagger{cx1client}, *RevertFile, keys, *Update, logger)
+		return
+	}
+
+	*EntityType = strings.ToLower(*EntityType)
+	if *EntityType != "all" && *EntityType != "project" && *EntityType != "application" {
+		logger.Fatalf("Invalid type %v, should be 'project', 'application' or 'all'", *EntityType)
+	}
+
+	var mapping Mapping
+	if *MappingFile != "" {
+		if mapping, err = ReadMapping(*MappingFile); err != nil {
+			logger.Fatalf("Failed to read mapping %v: %s", *MappingFile, err)
+		}
+		logger.Infof("Read mapping %v: %d synonyms, %d canonical keys, value mappings for %d keys", *MappingFile, len(mapping.Synonyms), len(mapping.Keys), len(mapping.Values))
+	} else if *Apply {
+		logger.Fatalf("The 'apply' flag requires a -mapping file - use -suggest to create one")
+	}
+
+	projects := []Cx1ClientGo.Project{}
+	apps := []Cx1ClientGo.Application{}
+	if *EntityType != "application" {
+		if projects, err = cx1client.GetAllProjects(); err != nil {
+			logger.Fatalf("Failed to get projects: %s", err)
+		}
+	}
+	if *EntityType != "project" {
+		if apps, err = cx1client.GetAllApplications(); err != nil {
+			logger.Fatalf("Failed to get applications: %s", err)
+		}
+	}
+	logger.Infof("Checking tags on %d projects and %d applications", len(projects), len(apps))
+
+	inventory := NewInventory()
+	for _, p := range projects {
+		inventory.Add(p.Tags, true)
+	}
+	for _, a := range apps {
+		inventory.Add(a.Tags, false)
+	}
+
+	if *Apply {
+		var revertLog *bulk.RevertLog
+		if *Update {
+			if *RevertLogFile == "" {
+				*RevertLogFile = bulk.RevertFilename("tag-normalize")
+			}
+			if revertLog, err = bulk.OpenRevertLog(*RevertLogFile); err != nil {
+				logger.Fatalf("Failed to open revert file %v: %s", *RevertLogFile, err)
+			}
+			defer revertLog.Close()
+			logger.Infof("Previous project tags will be recorded in %v - use -revert %v to undo the changes", *RevertLogFile, *RevertLogFile)
+		}
+		rewriteTags(cx1client, projects, apps, NewRewriter(mapping), revertLog, *Update, *Delay)
+		return
+	}
+
+	normalizer := NewNormalizer(mapping)
+	listInventory(inventory, normalizer)
+
+	if *ReportFile != "" {
+		if err = inventory.WriteCSV(*ReportFile); err != nil {
+			logger.Fatalf("Failed to write report %v: %s", *ReportFile, err)
+		}
+		logger.Infof("Wrote %d tag keys with their values to %v", len(inventory.Keys), *ReportFile)
+	}
+
+	if *SuggestFile != "" {
+		suggestion := inventory.Suggest(normalizer, mapping.Synonyms)
+		if err = WriteMapping(*SuggestFile, suggestion); err != nil {
+			logger.Fatalf("Failed to write mapping %v: %s", *SuggestFile, err)
+		}
+		logger.Infof("Wrote suggested mapping for %d keys and values of %d keys to %v - review it, then run with -mapping %v -apply", len(suggestion.Keys), len(suggestion.Values), *SuggestFile, *SuggestFile)
+	}
+}
+
+func listInventory(inventory *Inventory, normalizer Normalizer) {
+	logger.Infof("Found %d distinct tag keys:", len(inventory.Keys))
+	for _, key := range inventory.SortedKeys() {
+		stats := inventory.Keys[key]
+		logger.Infof(" - '%v': %v, %d distinct values", key, stats.Count.String(), len(stats.Values))
+
+		values := []string{}
+		for value := range stats.Values {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			logger.Debugf("    '%v': %v", value, stats.Values[value].String())
+		}
+	}
+
+	keyGroups := inventory.KeyGroups(normalizer)
+	if len(keyGroups) == 0 {
+		logger.Infof("No near-duplicate tag keys found")
+	} else {
+		logger.Warnf("Found %d groups of near-duplicate tag keys:", len(keyGroups))
+		for _, g := range keyGroups {
+			logger.Warnf(" - %v", describeGroup(g))
+		}
+	}
+
+	// values are grouped across all spellings of a key, so 'App: payments' and 'app: Payments ' are found together
+	keysByNorm := make(map[string][]string)
+	for key := range inventory.Keys {
+		norm := normalizer.Normalize(key)
+		keysByNorm[norm] = append(keysByNorm[norm], key)
+	}
+	norms := []string{}
+	for norm := range keysByNorm {
+		norms = append(norms, norm)
+	}
+	sort.Strings(norms)
+
+	valueGroupCount := 0
+	for _, norm := range norms {
+		groups := inventory.ValueGroups(keysByNorm[norm], normalizer)
+		if len(groups) == 0 {
+			continue
+		}
+		logger.Warnf("Near-duplicate values for key %v:", strings.Join(keysByNorm[norm], ", "))
+		for _, g := range groups {
+			logger.Warnf(" - %v", describeGroup(g))
+		}
+		valueGroupCount += len(groups)
+	}
+	if valueGroupCount == 0 {
+		logger.Infof("No near-duplicate tag values found")
+	}
+}
+
+func describeGroup(g VariantGroup) string {
+	variants := []string{}
+	for _, v := range g.Variants {
+		variants = append(variants, fmt.Sprintf("'%v' (%v)", v, g.Counts[v].String()))
+	}
+	return fmt.Sprintf("%v -> suggested '%v'", strings.Join(variants, ", "), g.Canonical())
+}
+
+// rewriteTags updates every project and application whose tags change under the mapping, showing the changes first.
+// The previous tags of each project are recorded in the revert log before it is updated, application tags are not.
+func rewriteTags(cx1client *Cx1ClientGo.Cx1Client, projects []Cx1ClientGo.Project, apps []Cx1ClientGo.Application, rewriter Rewriter, revertLog *bulk.RevertLog, update bool, delay int) {
+	type change struct {
+		name    string
+		tags    map[string]string
+		project *Cx1ClientGo.Project
+		app     *Cx1ClientGo.Application
+	}
+
+	changes := []change{}
+	conflicts := 0
+	check := func(name string, tags map[string]string) (map[string]string, bool) {
+		rewritten, err := rewriter.Rewrite(tags)
+		if err != nil {
+			logger.Errorf("Skipping %v: %s", name, err)
+			conflicts++
+			return nil, false
+		}
+		return rewritten, !maps.Equal(tags, rewritten)
+	}
+
+	for i := range projects {
+		name := "project " + projects[i].String()
+		if tags, changed := check(name, projects[i].Tags); changed {
+			changes = append(changes, change{name: name, tags: tags, project: &projects[i]})
+		}
+	}
+	for i := range apps {
+		name := "application " + apps[i].String()
+		if tags, changed := check(name, apps[i].Tags); changed {
+			changes = append(changes, change{name: name, tags: tags, app: &apps[i]})
+		}
+	}
+
+	logger.Infof("%d projects and applications need their tags rewritten, %d skipped due to conflicts", len(changes), conflicts)
+
+	updated, failed := 0, 0
+	for i, c := range changes {
+		progress := fmt.Sprintf("[#%d/%d] ", i+1, len(changes))
+		var before map[string]string
+		if c.project != nil {
+			before = c.project.Tags
+		} else {
+			before = c.app.Tags
+		}
+
+		logger.Infof("%v%v:", progress, c.name)
+		for _, line := range bulk.TagChanges(before, c.tags) {
+			logger.Infof("    %v", line)
+		}
+
+		if !update {
+			continue
+		}
+
+		var err error
+		if c.project != nil {
+			if revertLog != nil {
+				if err = revertLog.Record(c.project.ProjectID, c.project.Name, before, c.tags); err != nil {
+					logger.Fatalf("Failed to record previous tags of %v in %v: %s", c.name, revertLog.Filename, err)
+				}
+			}
+			c.project.Tags = c.tags
+			err = cx1client.UpdateProject(c.project)
+		} else {
+			c.app.Tags = c.tags
+			err = cx1client.UpdateApplication(c.app)
+		}
+		if err != nil {
+			logger.Errorf("%vFailed to update %v: %s", progress, c.name, err)
+			failed++
+		} else {
+			logger.Infof("%vUpdated %v", progress, c.name)
+			updated++
+		}
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+	}
+
+	if update {
+		logger.Infof("Done - %d updated, %d failed, %d skipped due to conflicts", updated, failed, conflicts)
+	} else {
+		logger.Infof("Done - %d would be updated, %d skipped due to conflicts", len(changes), conflicts)
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+	}
+}
+
+// projectTagger lets bulk.RevertTags read and write project tags
+type projectTagger struct {
+	cx1client *Cx1ClientGo.Cx1Client
+}
+
+func (t projectTagger) GetProjectTags(projectID string) (string, map[string]string, error) {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	return project.String(), project.Tags, err
+}
+
+func (t projectTagger) SetProjectTags(projectID string, tags map[string]string) error {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	if err != nil {
+		return err
+	}
+	project.Tags = tags
+	return t.cx1client.UpdateProject(&project)
+}
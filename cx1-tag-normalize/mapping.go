@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mapping is the content of the mapping YAML file:
+//   - synonyms group different words as near-duplicates, eg: app: [application, apps]
+//   - keys and values map each canonical spelling to the variants that should be rewritten to it
+type Mapping struct {
+	Synonyms map[string][]string            `yaml:"synonyms,omitempty"`
+	Keys     map[string][]string            `yaml:"keys,omitempty"`
+	Values   map[string]map[string][]string `yaml:"values,omitempty"` // canonical key -> canonical value -> variants
+}
+
+func ReadMapping(filename string) (Mapping, error) {
+	var m Mapping
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return m, err
+	}
+	if err = yaml.Unmarshal(data, &m); err != nil {
+		return m, err
+	}
+	return m, m.validate()
+}
+
+func WriteMapping(filename string, m Mapping) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// validate checks that no variant is mapped to two different canonical spellings
+func (m Mapping) validate() error {
+	seen := make(map[string]string)
+	for canonical, variants := range m.Keys {
+		for _, v := range variants {
+			if other, ok := seen[v]; ok && other != canonical {
+				return fmt.Errorf("key '%v' is mapped to both '%v' and '%v'", v, other, canonical)
+			}
+			seen[v] = canonical
+		}
+	}
+
+	for key, values := range m.Values {
+		seen = make(map[string]string)
+		for canonical, variants := range values {
+			for _, v := range variants {
+				if other, ok := seen[v]; ok && other != canonical {
+					return fmt.Errorf("value '%v' of key '%v' is mapped to both '%v' and '%v'", v, key, other, canonical)
+				}
+				seen[v] = canonical
+			}
+		}
+	}
+	return nil
+}
+
+// Normalizer reduces keys and values to a form in which near-duplicates are equal: lower case, single spaces, and synonyms replaced
+type Normalizer struct {
+	synonyms map[string]string // normalized alias -> normalized canonical word
+}
+
+func NewNormalizer(m Mapping) Normalizer {
+	n := Normalizer{synonyms: make(map[string]string)}
+	for canonical, aliases := range m.Synonyms {
+		for _, alias := range aliases {
+			n.synonyms[n.fold(alias)] = n.fold(canonical)
+		}
+	}
+	return n
+}
+
+func (n Normalizer) fold(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func (n Normalizer) Normalize(s string) string {
+	folded := n.fold(s)
+	if canonical, ok := n.synonyms[folded]; ok {
+		return canonical
+	}
+	return folded
+}
+
+// Rewriter applies the canonical keys and values of a mapping to a tag map
+type Rewriter struct {
+	keys   map[string]string            // variant -> canonical key
+	values map[string]map[string]string // canonical key -> variant -> canonical value
+}
+
+func NewRewriter(m Mapping) Rewriter {
+	r := Rewriter{
+		keys:   make(map[string]string),
+		values: make(map[string]map[string]string),
+	}
+	for canonical, variants := range m.Keys {
+		for _, v := range variants {
+			r.keys[v] = canonical
+		}
+	}
+	for key, values := range m.Values {
+		r.values[key] = make(map[string]string)
+		for canonical, variants := range values {
+			for _, v := range variants {
+				r.values[key][v] = canonical
+			}
+		}
+	}
+	return r
+}
+
+// Rewrite returns the tags with canonical keys and values. If two keys are rewritten to the same key with different values, an error is returned and the tags should be left unchanged.
+func (r Rewriter) Rewrite(tags map[string]string) (map[string]string, error) {
+	rewritten := make(map[string]string)
+	origin := make(map[string]string)
+
+	for key, value := range tags {
+		newKey := key
+		if canonical, ok := r.keys[key]; ok {
+			newKey = canonical
+		}
+		newValue := value
+		if canonical, ok := r.values[newKey][value]; ok {
+			newValue = canonical
+		}
+
+		if existing, ok := rewritten[newKey]; ok && existing != newValue {
+			return tags, fmt.Errorf("tags '%v' = '%v' and '%v' = '%v' would both become key '%v' with different values", origin[newKey], tags[origin[newKey]], key, value, newKey)
+		}
+		rewritten[newKey] = newValue
+		origin[newKey] = key
+	}
+
+	return rewritten, nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+var logger *logrus.Logger
+
+type Conflict struct {
+	Project    *Cx1ClientGo.Project
+	TaggedApps []string
+	MemberApps []string
+}
+
+func main() {
+	logger = logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	logger.Info("Starting")
+	logger.Info("The purpose of this tool is to group projects into applications based on a project tag - the reverse of cx1-app-to-tag.")
+
+	httpClient := &http.Client{}
+
+	TagName := flag.String("tag", "app", "Tag key to use - the tag value is the application name. If empty, -regex is matched against every tag as 'key=value'")
+	TagRegex := flag.String("regex", "", "Optional: regular expression with one capture group, matched against the tag value (or 'key=value' if -tag is empty) - the captured text is the application name")
+	Create := flag.Bool("create", true, "Create applications that do not exist yet")
+	Change := flag.Bool("update", false, "No changes will be made unless this flag is set to true")
+
+	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
+	if err != nil {
+		logger.Fatalf("Error creating client: %s", err)
+	}
+
+	if *TagName == "" && *TagRegex == "" {
+		logger.Fatalf("At least one of the tag or regex parameters must be set")
+	}
+
+	var valueRE *regexp.Regexp
+	if *TagRegex != "" {
+		if valueRE, err = regexp.Compile(*TagRegex); err != nil {
+			logger.Fatalf("Invalid regex %v: %s", *TagRegex, err)
+		}
+		if valueRE.NumSubexp() != 1 {
+			logger.Fatalf("The regex %v must have exactly one capture group", *TagRegex)
+		}
+	}
+
+	if *TagName != "" {
+		logger.Infof("Projects will be assigned to the application named in their %v tag", *TagName)
+	} else {
+		logger.Infof("Projects will be assigned to the applications captured by %v from any of their tags", *TagRegex)
+	}
+
+	if *Change {
+		logger.Warn("The 'update' flag is set - changes will be applied")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	logger.Infof("Connected with %v", cx1client.String())
+
+	projects, err := cx1client.GetAllProjects()
+	if err != nil {
+		logger.Fatalf("Failed to get projects: %s", err)
+	}
+
+	apps, err := cx1client.GetAllApplications()
+	if err != nil {
+		logger.Fatalf("Failed to get applications: %s", err)
+	}
+
+	AppsByID := make(map[string]*Cx1ClientGo.Application)
+	AppsByName := make(map[string]*Cx1ClientGo.Application)
+	for id, app := range apps {
+		AppsByID[app.ApplicationID] = &apps[id]
+		AppsByName[app.Name] = &apps[id]
+	}
+
+	ProjectsByApp := make(map[string][]*Cx1ClientGo.Project)
+	conflicts := []Conflict{}
+	untagged := 0
+
+	for id := range projects {
+		project := &projects[id]
+		names := taggedApplications(project, *TagName, valueRE)
+		if len(names) == 0 {
+			untagged++
+			continue
+		}
+
+		for _, name := range names {
+			ProjectsByApp[name] = append(ProjectsByApp[name], project)
+		}
+
+		others := []string{}
+		for _, appId := range project.Applications {
+			if app, ok := AppsByID[appId]; !ok {
+				logger.Errorf("Project %v is linked to unknown application with ID %v", project.String(), appId)
+			} else if !slices.Contains(names, app.Name) {
+				others = append(others, app.Name)
+			}
+		}
+		if len(others) > 0 {
+			conflicts = append(conflicts, Conflict{project, names, others})
+		}
+	}
+
+	logger.Infof("Found %d tagged projects for %d applications, %d projects have no matching tag", len(projects)-untagged, len(ProjectsByApp), untagged)
+
+	appNames := []string{}
+	for name := range ProjectsByApp {
+		appNames = append(appNames, name)
+	}
+	slices.Sort(appNames)
+
+	created, updated, assigned, failed := 0, 0, 0, 0
+
+	for _, name := range appNames {
+		app, exists := AppsByName[name]
+		if !exists {
+			if !*Create {
+				logger.Warnf("Application %v does not exist and -create is not set - skipping %d projects", name, len(ProjectsByApp[name]))
+				continue
+			}
+
+			if !*Change {
+				logger.Infof("Would create application %v", name)
+				app = &Cx1ClientGo.Application{Name: name}
+			} else {
+				newApp, err := cx1client.CreateApplication(name)
+				if err != nil {
+					logger.Errorf("Failed to create application %v: %s", name, err)
+					failed++
+					continue
+				}
+				logger.Infof("Created application %v", newApp.String())
+				app = &newApp
+				AppsByName[name] = app
+			}
+			created++
+		}
+
+		logger.Infof("Checking application: %v", app.String())
+		count := 0
+		for _, project := range ProjectsByApp[name] {
+			if slices.Contains(project.Applications, app.ApplicationID) && app.ApplicationID != "" {
+				logger.Debugf(" - project %v is already in the application", project.String())
+				continue
+			}
+			logger.Infof(" - assigning project %v", project.String())
+			app.AssignProject(project)
+			count++
+		}
+
+		if count == 0 {
+			logger.Infof(" - Application %v required no changes.", app.String())
+			continue
+		}
+
+		if !*Change {
+			logger.Infof(" - Not applying changes, use --update to apply.")
+			assigned += count
+		} else if err = cx1client.UpdateApplication(app); err != nil {
+			logger.Errorf("Failed to update application %v with %d new projects: %s", app.String(), count, err)
+			failed++
+		} else {
+			logger.Infof(" - Updated application %v with %d new projects", app.String(), count)
+			assigned += count
+			updated++
+		}
+	}
+
+	if len(conflicts) > 0 {
+		logger.Warnf("%d projects are members of applications that do not match their tags:", len(conflicts))
+		for _, c := range conflicts {
+			logger.Warnf(" - project %v is tagged for %v but is also in %v", c.Project.String(), strings.Join(c.TaggedApps, ", "), strings.Join(c.MemberApps, ", "))
+		}
+	}
+
+	if *Change {
+		logger.Infof("Done - %d applications created, %d updated with %d projects, %d failed", created, updated, assigned, failed)
+	} else {
+		logger.Infof("Done - %d applications would be created, %d projects would be assigned", created, assigned)
+		logger.Warnf("No changes were applied. To apply changes, re-run with the --update flag set.")
+	}
+}
+
+// taggedApplications returns the application names derived from the project's tags
+func taggedApplications(project *Cx1ClientGo.Project, tagName string, valueRE *regexp.Regexp) []string {
+	names := []string{}
+	add := func(value string) {
+		if valueRE != nil {
+			m := valueRE.FindStringSubmatch(value)
+			if m == nil {
+				return
+			}
+			value = m[1]
+		}
+		if value = strings.TrimSpace(value); value != "" && !slices.Contains(names, value) {
+			names = append(names, value)
+		}
+	}
+
+	if tagName != "" {
+		if value, ok := project.Tags[tagName]; ok {
+			add(value)
+		}
+	} else {
+		for key, value := range project.Tags {
+			add(fmt.Sprintf("%v=%v", key, value))
+		}
+		slices.Sort(names)
+	}
+
+	return names
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// cleanup deletes the objects created by one seeding run: the projects and applications tagged with the run label, the top-level groups and users
+// named with the run label prefix, and everything recorded in the run's manifest.
+// Scans are removed together with their projects, and child groups together with their parent group.
+func cleanup(cx1client *Cx1ClientGo.Cx1Client, label string, delay int, update bool) {
+	entries, err := ReadManifest(label)
+	if err != nil {
+		logger.Warnf("Failed to read manifest %v: %s - only projects and applications tagged %v=%v, and groups and users named %v* will be removed", manifestFilename(label), err, labelTag, label, labelPrefix(label))
+	} else {
+		logger.Infof("Read %d entries from manifest %v", len(entries), manifestFilename(label))
+	}
+
+	_, projects, err := cx1client.GetAllProjectsFiltered(Cx1ClientGo.ProjectFilter{
+		BaseFilter: Cx1ClientGo.BaseFilter{Limit: 100},
+		TagsKeys:   []string{labelTag},
+		TagsValues: []string{label},
+	})
+	if err != nil {
+		logger.Fatalf("Failed to get projects tagged %v=%v: %s", labelTag, label, err)
+	}
+	projects = slices.DeleteFunc(projects, func(p Cx1ClientGo.Project) bool { return p.Tags[labelTag] != label })
+
+	_, apps, err := cx1client.GetAllApplicationsFiltered(Cx1ClientGo.ApplicationFilter{
+		BaseFilter: Cx1ClientGo.BaseFilter{Limit: 100},
+		TagsKeys:   []string{labelTag},
+		TagsValues: []string{label},
+	})
+	if err != nil {
+		logger.Fatalf("Failed to get applications tagged %v=%v: %s", labelTag, label, err)
+	}
+	apps = slices.DeleteFunc(apps, func(a Cx1ClientGo.Application) bool { return a.Tags[labelTag] != label })
+
+	prefix := strings.ToLower(labelPrefix(label)) // user names are lower case in Cx1
+	_, users, err := cx1client.GetAllUsersFiltered(Cx1ClientGo.UserFilter{
+		BaseIAMFilter: Cx1ClientGo.BaseIAMFilter{Max: 100},
+		Search:        labelPrefix(label),
+	})
+	if err != nil {
+		logger.Fatalf("Failed to get users named %v*: %s", labelPrefix(label), err)
+	}
+	users = slices.DeleteFunc(users, func(u Cx1ClientGo.User) bool { return !strings.HasPrefix(strings.ToLower(u.UserName), prefix) })
+
+	_, groups, err := cx1client.GetAllGroupsFiltered(Cx1ClientGo.GroupFilter{
+		BaseIAMFilter:       Cx1ClientGo.BaseIAMFilter{Max: 100},
+		BriefRepresentation: true,
+		Search:              labelPrefix(label),
+	}, false)
+	if err != nil {
+		logger.Fatalf("Failed to get groups named %v*: %s", labelPrefix(label), err)
+	}
+	groups = slices.DeleteFunc(groups, func(g Cx1ClientGo.Group) bool { return !strings.HasPrefix(g.Name, labelPrefix(label)) })
+
+	for _, e := range entries {
+		switch e.Type {
+		case "project":
+			if !slices.ContainsFunc(projects, func(p Cx1ClientGo.Project) bool { return p.ProjectID == e.ID }) {
+				projects = append(projects, Cx1ClientGo.Project{ProjectID: e.ID, Name: e.Name})
+			}
+		case "application":
+			if !slices.ContainsFunc(apps, func(a Cx1ClientGo.Application) bool { return a.ApplicationID == e.ID }) {
+				apps = append(apps, Cx1ClientGo.Application{ApplicationID: e.ID, Name: e.Name})
+			}
+		case "user":
+			if !slices.ContainsFunc(users, func(u Cx1ClientGo.User) bool { return u.UserID == e.ID }) {
+				users = append(users, Cx1ClientGo.User{UserID: e.ID, UserName: e.Name})
+			}
+		case "group":
+			if !strings.Contains(e.Name, "/") && !slices.ContainsFunc(groups, func(g Cx1ClientGo.Group) bool { return g.GroupID == e.ID }) {
+				groups = append(groups, Cx1ClientGo.Group{GroupID: e.ID, Name: e.Name})
+			}
+		}
+	}
+
+	deleted, failed := 0, 0
+	remove := func(progress, objectType, name string, delete func() error) {
+		if !update {
+			logger.Infof("%v Would delete %v %v", progress, objectType, name)
+			return
+		}
+		if err := delete(); err != nil {
+			logger.Errorf("%v Failed to delete %v %v: %s", progress, objectType, name, err)
+			failed++
+		} else {
+			logger.Infof("%v Deleted %v %v", progress, objectType, name)
+			deleted++
+		}
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+	}
+
+	for i := range projects {
+		remove(fmt.Sprintf("[#%d/%d]", i+1, len(projects)), "project", projects[i].String(), func() error { return cx1client.DeleteProject(&projects[i]) })
+	}
+	for i := range apps {
+		remove(fmt.Sprintf("[#%d/%d]", i+1, len(apps)), "application", apps[i].String(), func() error { return cx1client.DeleteApplication(&apps[i]) })
+	}
+
+	for i := range users {
+		remove(fmt.Sprintf("[#%d/%d]", i+1, len(users)), "user", users[i].UserName, func() error { return cx1client.DeleteUserByID(users[i].UserID) })
+	}
+	for i := range groups {
+		remove(fmt.Sprintf("[#%d/%d]", i+1, len(groups)), "group", groups[i].Name, func() error { return cx1client.DeleteGroup(&groups[i]) })
+	}
+
+	if update {
+		logger.Infof("Cleanup of run %v finished - %d objects deleted, %d failed", label, deleted, failed)
+	} else {
+		logger.Infof("Cleanup of run %v would delete %d projects, %d applications, %d users and %d groups", label, len(projects), len(apps), len(users), len(groups))
+	}
+}
@@ -0,0 +1,4 @@
+package main
+
+var resourceCodeZip = []byte(
+	"PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00t\x03\x00\x00\x11\x00 \x00code/apexFile.clsUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00u\x92\xcdn\xc20\x10\x84\xef<\x85o\x04\tb\xf5ZJ\x05BP!\xd1R\x15nU\x85\x1cg\x01\xabN\xecz\x9d\x82Z\xf1\xee\xb5\xf3\a\x81\x92S\xbc\xe3\xd9\xfdF\xeb\xa1\xc0\x15\xa0m\xe9,\x92\x82\x13.\x19\"a\x1a\x0eS!\x81\xfc\xb6\x88\xfb(%\\i\x011\xd9\x18\x95\x108\xb0D;qg\xad\xc6{J\xb7\xc2\xee\xb2(\xe4*\xa1\xd60!w\xc0b\xa65R\x88\xc4'`O\xee9\x8d\xa4\x8ah\xc2DJ7\xcap\xe89\xbd8ưa\x99\xb44\x1f\fH=\xcc\xc2\xc4`\xc6*\xb5FI\t&\xe4\x12s\x8e\xa1u\xe2\x12l\xa6\xf3#Zf\x1d\xf2\xb7\x121A_\r:%\xb0\xffF\x9c\xab,\xb5\x84qN\x06$\x85}U\t^X\x02\xae\xd4^\x161\xcar\xbbӯ\xbd\"E0\xb9\xb5ߪ\x8b9\xd5z͉\xf2?eϪ\x18\x94]\xbc>\xf0\xc6p\x16_7̝g-_\x8d\x8a3\x9e\x9bt\xd9\xf0T\xba\xe4,\x95\x7f8\xf5\x15\xe5\xccBR\xa3\xfaC\x03\xb7T\x83\xdaԈ7(\\.@\xb7q\xe1\fv@\xb4\x93k\xf5FP?\xa6P\x8e\x05_\xf5\xd6.\x97\xe7\xd7\xfa\x04\xc5ڽ\t\x1b\x8b\xac\xc1l\xf54\xdc\xfc\xf7\xe5d>\x19\xaf\xc8,&ӷ\xc5s}\xe9\xe3\x842\x17h\x1f\x1a\x81\x1fO\\\xe8z\\>\xb3m\x03\xa1\x91\xbd\x1e}#\xf6\b}\xec\x90\x19\x98|eL\x06wݳQ!\x8a\x1f\b:\xe5\xf5c\xeb\xf8\aPK\a\b\xf8'\xe7n~\x01\x00\x00t\x03\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xe0\x0e\x00\x00\x10\x00 \x00code/aspFile.aspUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\xbdW[O\xdb0\x14~ﯰ<Ub\x93h\xc4\x1eQ\x1a\r\xd22*\x01뀍\xc7ɵ\x0f\xadEjg\xf6I\x81\x97\xfd\xf6\xd9IzK\x13\xd4\xcbF\x1e*_\xce\xf9\xbes\xbe\x1c\x9f\xd4a\xfb\v\x19\xb21\x90{\x89\tt\xe9\x03\x8c\xbej\x86\x9d\x1b@rL\xfaB\"\x19\x1a-2\x8e\x94\\15Μm\x97\xc6\x1f(\xb9f\x16\xc1x\xdf\v\xe9=\xff\x04w\x12\xa1S,\xd3\x16q\xcfY\x86\xba?\x03\x85\x0f\xd2@\x96v)\x9a\f(\x89\xb5\x80s\x98H%\xba\xd43\x94\x04\x1dfӗ\x0e\xb7\x94\f\xd4\x04\x8cD\x9b\x87\x93\xa3\x9e\x89\xa9T\x9d\x15cJ\xdaQ\xab\x15:\x97\xd3X+t\x1cd\xd0s\x81\x15\xe3\x13O\x92\x8f\x86\t\xe3p\xa9\x13\x01\xc6\xef_\x02\x13\xe5\x0e%&S\f\xbbԂ\x99\xb9\x88\xa3V\x18\xac\xc0E\x8d\xe0\x9f\x9b\xc0\xaf\x99T\x8d\xe0^\x8fprR\f\xfc\xb3\xaam\x18\xccwr\xd2+6\x82$\xa7LF\xc95X\xebD\xde@,\xa2\xcdMKב!A9\x14rFx¬\x93\x90q\xae3\x85\x03\xf5\xa8\xe9\x92=|\x94\x90\b\v\xb8\\ʗ\x13\x18\x83\x12Q\x19\x17\x91\xce\xcbL\x19J\xad\u00a0\xdc[wH\xd7\xe759\x94X\x03\x91/U\xf3 g\xd6j.\x19B\xfeb\x8cNʼ\x17nt\x11\xcd@\x9c\xaef\xbd\x92\xf0\x1b\xf4kPU\xf2\xd8ڸ\x90\t\xe1\x05\xfb\x8e\xff\xb5F\xd9\x05r\x90\xee\x9c\xfd\x92\xfe\x86Ma\xbb\xec\xf1\x05W}\x16\xf9\xfb\xd9\xd6\nܻ\x84\xce\xf5\v\xa9\x01\xdcA\x85\x12\xa5\x81\xe3\x16~g\xeeh\x8b\v_L?Y\"\x05Cmr\xca\xfa\xad\x93M\xee\"\xed{]\xda\xc0F\xb4\x1b\xd4\xfeY\x86\xfc\xc8d\x92\x19\xb8\xd1(\x1f%\xcfK\x95\x92\xbe1ڔ'gQ\x81D98\"-1el\x1dJ\xee\xb5\xe3\x96\xe9[6\xb5\xfcѧB\x9f\xfa4\xa3Z\x9f\xb0]\xbb\x9cj\x8b\xae\x15tsJ\xb0\xd8q\xbf\xe6բ\x91j|Dq\"\xad\xb7\xa0\x1fk\x9d\r\xb8]e\xa1\xf3\xec\x1a&\x1c\x15X\xf5\xa6\xed\x7fṞ{Ecm^k\x0eSm-\v\xb1\xea\x12\xcd\xc7[\x97q\xcf货\x9fՕ\xb4E\x17^\a$q\"]\xbf\x1d\xf4\xae\xddG\xa5K\xef\xd0\x15\x00ߥ\xc0W\xf1\x0f\x17\xe8{Ɣ{\x01\xafC0?\x94\xc4\x1d:\x9e+\xfa\x8a3\x8d\xe6\v$\x05C2\xb7\xb4\xef\xe1\xaf\"\xbfG\x03\xa8p\xce-\xb7l\x00Ո\x0fo\x02\xfd\xe3\xa93j:\xfeu\xbb\a\x1f\xfc=\x8f\x98Oxh$\xdf\xfek\xb1\xf4\x88\xfc\x90\xa4~\xbco\xb14\xd3\xefZ&\a\xe4o\a\xea\x0e5\x7f\xdaI\x02+K\xa7\\\x05\xeb\xfe\xbd\x10\xeb\xe7\x87(\xd1\x18\xc8{\x8a\xf1M}3\u0093\xee\"\xc6ܩ\x14C+\xa2\xfd\xfc 1\x9a\x02y'1n!O\xe1\nf;\xb4\xd55\xa7\xa8\x9c\x91\xc4O\xf7\xd5\xe2\xcd8\xfe\x9f\x16\xf1\x04\xf8\x93\x0fC\xba\x9b\x13\x9f<\xf5\xa4\xe5.U\xa9\xb2\x9a\xbe깺t\xdd$x\x839\f6\xaf\x04a:\xbfE\xd8l4\x95x\x9e!\xba\xceZA\xf1\xa1\x15;\xb9>#Twl\x06\xf1\xc4]\x17\xc16\xc4\xe5-\b\x9f\x9bl\xa4\xab\x15O$\x7f\xaa\x82\xfd\x8a\xfd\xeaZ\x1e\x8b\x1c\xc2\xc0]z*\x97\xb8\xa8\xf5\x17PK\a\b\x8a-\bV\\\x03\x00\x00\xe0\x0e\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xfc\x00\x00\x00\x12\x00 \x00code/cobolFile.cblUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00e\x8e\xd1\n\x82@\x10E\xdf\xfd\x8ay\xb3\x82\xfc\x84@ܭ\x86VWԊ\x1eEW\x1b\xcaUֵ\xefo\r\x8a\xa2\xb7\xc30\xf7ܻ\xda\xc0\xa8\xec4\x80\xbd*\xa0ZiK\rU\xa5\xa5^CM\x0f\x1a\x1dx\xc8xR\xe0\x16\xa3\xb0@\x99\x00\xc3\x13\xe6\x0e\x02\xef'=\x98\xbe5e\xe7,^\x9a\xc9]\x16\xc6kd\x01\xec\xb9\x10\xf2\xff\xb5R\xf5dԧ\x03\x16w\xba)\U0003b4b4\x0fͤ\xaby\xc2r6E\x9c\x1d3\xfe\xd5\n\xe0d\x83!m\xa1\x84\xd1:hݍa\x9e\x8a\xf0\x02\xfe\x19\x858\xc88\xe6\x89\xff\xaaU\xba\x86~2\xef}^^\xc8\x14\xb2c\x12<\x01PK\a\b\xa0\xab=c\xb1\x00\x00\x00\xfc\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xd0\x00\x00\x00\x16\x00 \x00code/cplusplusFile.cppUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00M\x8d\xbb\n\x021\x14D\xfb\xfb\x15\xe3\xda\xf8(|u\xbb\xab͂\xd8\bvb\x19\x93\xb8\x06\xb3\xb9p\x93EE\xfcwWAq\xcaaΙ\xc9\x04\an\x05k'1\xa1\x1a\x8f\xb1\x13\xaeE5D}\x17\xb4o\x8dE\xe98&\xb1\xaaY\x11i\xafb\xc4\xf6^q8\xb9\x1a\x0fB\x97\x98Tr\x1a\x9aC\xe7\xd0g%#\x98\xe3\xae\x1b^Y\f\x96Ȫ\xb3\u0557Fɭ7\x9b/\xb2\x82\x9eD.$4ʅ\xc1\xf0'1y\xae\xb9M(Kd\x1b\xeb=c\xcf\xe2M\x0fٻ\xfa~\xe6\xf9\x9f\xbb\xf8\xa0bS+\x01ӂ\x9e/PK\a\b\x97\xf1\xa1d\xa3\x00\x00\x00\xd0\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xc9\x00\x00\x00\x12\x00 \x00code/csharpFile.csUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00]L\xbb\n\xc20\x14\xdd\xf3\x15\xd7~@A\x1d\x8b\x83&\xe2\"(f\xe8X\xd2p\xd1`\x1e\xb57\x85\x8a\xf4\xdfM\xe3\"=\xcb\xe1<\a2\xfe\x0e\xf2M\x11]\xc5\xfeUɃ\xb5\xa8\xa3\t\x9e\xca\x13z\xec\x8d^4\xceƿ\x16V\x8dmŘW\x0e\xa9S\x1a\x81\x8f\xfc\xd8\bt\x81}\x18$h\xab\x88\x80\xa7Ϩ|\xa4\xec\xfd\x92\x19\xdd\xd0Z\xa3!e1S??\x8bCs\xddKY_n\x02vP\xf0\a\xea\xa7S\xfd\xb8\xdelWE\x95\xb7\x13\x9b\xbePK\a\b\xf9t\xba;\x8d\x00\x00\x00\xc9\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xed\x05\x00\x00\x12\x00 \x00code/dartFile.dartUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\xa5TKk\xdc0\x10\xbe\xfbW\xcc\xcd^\xd8:\x97\x9e6\x0f\xbaI\b\xcd!\x10H\xa0\x94R\x82\xd6\x1a\xafEd\x8d+\xc9IL\xd8\xffޑl\xaf\xbd\xc9\xd2K/z\xcch^\xdf|\x1aU7d=\xa4\x8d(\x9e\xc5\x16W\xa5n\xbdG{R\v^\x95й\x14֧\xa7I\xf2BJB-\x94\xc9\x16\xf0\x9e\x00\xd8֬\x9b&+\xc88\x0fw]8/\x16\xa7\xc9.I\n-\x9c\xebE\x80o\x1e\x8dt\xf0\xe0ٟF\xe7~(\xb9E\x1f=\xccM\xdf]۠͟\xb1۱\x13V\x9e\x9c\xc0c\xa5\x1c\xbc\xf6\xef\xf9\xe4+\x04K\xe4\x81J訵 \x9aF\xabBxE&g\x8bo\xf4\x82\xd6*\x89|\x1e\xa2lZ\xa5ev\x19\xd6+2\x9e\x93\tA\xc3\xde\xd7\xc0U\xa0o\xad\x81\xbb\xa1ڐJ\x94\x03x\xe55\xae \xbd\xe9\x01\x81k\xac)]\x8e\xca\nkV>\x86\xedZx1\x1a\x014V\xd5\xc2v\x0f\xaf\xc2\x17\xd5\n\xaeH\x93u\xf9F\xb78\xda.\xc6CE\xc1ǈ\xc2w\xbe\xdds\a\xb2c\x81!h!\xa8\xd3\xc1\x9cQ\x02\xd8\xcd\xe1\x1e\x1d\x1cb^\xb6\xfa\b\xe4\xfb`\x13\xeeK\xc6\xe2O\xab,J\xaeN\xb9<\xa614\xa3TFh\xf6g\x95\xd9\xf6\xc0D\xf1\x1c\xf1\x18\xecl\xf2|\x01\x85E\x16E9S\xe6\xfc\x02\x9e&\xed \x9d\xd1\xe5\xa3\xf2\xb0\x8a\x03\xc7\xef1\\̥a\xd3W\xb2\x12\xce!\xbd\xeb\xc6[\x1a\xb0\x89|}R\x86Ө\xd1\xf8+j\xb9\xf16\x1b\x1b\xef\xd0\xf7I\x04\xc9n@\xf3?Y\xf4P\x88\xb2$~;4\x98\tz)\xec\n\xd6q\x9f82t\xf8\x91=d=\xbf{\xb4\x17\x9f(\xb2!\xd91\x890\xa6\xbe\xb7/*N%r\xab\xad\xcd$\x86\xf8=\xd7oʭ\xb5ښP\xf5\x8a\x99\xfdA\x94\x17\xd1\xdbrf\x16\xfdY4+8\xebK\xbe\xf85ӎ\xac\x89\xe9\x1e\xc8\x01ҟ\xe1#\ueed0.\x0f\xf4\x8b\xc3\xeb1\a\xa3\xe9\xf2\x83\xdc\xf9N\x8f\x1f,\xa72\x1b\x01\xcf\xc3\xdaK+\x14R+\x83_\xff\x11\xf4\xf7t\xf9\fn\xa9\x89\x87\x87ٮ\x8b0B.\xf9\xb7\x11CpsD:eM\xe6\xde\xf2\x14C\x86\xff\x13\xb7\xa6X\x9eH{\xd5\xf0'\xbe\x1d\xdf̠\x19\xdaףz\xcb[\x16\x16\x97\v)gI\xee'\xa0\xb7B\xe9@\xf6\x82\xeaZp\x8f\x9fсh=})\xc9\xf2\x98\x0eɂQ\x05\x8f\n\x16\xf4l\x85\x1a}E\xd2\xe5\xf3Y\xb1K\xfe\x02PK\a\bg\x0e\xfdZ\x83\x02\x00\x00\xed\x05\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\x13\x01\x00\x00\x0f\x00 \x00code/DockerfileUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00M\x8dAK\xc3@\x10\x85\xef\xfb+\x1e\xed\xc1S\xb2֓\x14<\x94\xb4B(5\x92(\n\xe2a\x9al\xe2\xdaug\xd9\xddh\xfb\xef]\x1a\xc1\xc2\xc0c\xbe\xf7\xde\xcc\x1c\xe1d#\x1d\xef:n\x0f\xca\xcbIzm\xd4r!\xee\xebj\x87\x81\r\xd9aI\xc6i\xab\xc4\x1c\xf1C\a\xa4\xb1\x1cA\x7f.\xc89P\x1bG2\xe6\x84\xc0H\n\xee\xa7\xec\x8fNKO\xda\xe4(\xe3U\xc0\xe7\x18\"\"cPVy\x8a\n۲hd\xb9*\xe0U\x18M\f\xa2~~\xc0ס\xd3\x1e\x99\x83d\x17e\b{\x12/U\xbd]\x97\xf5\x05i٥w\xbe\x9dP\xb3j\x9en\x8a\xe3B&\"\xceG\x06\xc6~ԦC\xf6\x8d\x8c\xff\x8bȅؼ>V\xcd\x06\xb7עح\xf16\xcb\xcf\xce\xec]\xfc\x02PK\a\bD\xc1\xd8s\xd2\x00\x00\x00\x13\x01\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00q\x00\x00\x00\x0e\x00 \x00code/goFile.goUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\x1d\xcc=\x0e\x830\f@\xe1\xb9>\x85\xf1\x04K%`f\xea\x05\xb8\x82I\x02D\xe4\a9\x81\"\xa1\u07bd\x11\xfb\xf7\xde\xcej\xe3Šg\x1b\x00\xacߣd\xa4\xd9g\x028YPO#\xa7\xf4\x8d\xa21e\xb1a\xc1\x01\xe9\xb3\x1a\xb5y\x96\xabj\xbb\xbe\xc0\xf9\b\xea9\xd4\r\xde\xf0*\xf5{,6\xbbP\xd3j\x9c\x8bX\x06NS\x03?\xf8\x03PK\a\bm\xe9A\x8bj\x00\x00\x00q\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00H\x00\x00\x00\x16\x00 \x00code/groovyFile.groovyUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\v.)\xca\xccKW(H,..\xcf/JQ\xb0UPr\xceHM\xce\xceM,\xaaP442V\xb2\xe6*\x00\xaa(\xc9\xc9S\xd0P\xf2H\xcd\xc9\xc9W\b\xcf/\xcaIQTPRІkӴ\x06\x00PK\a\bӏ\xea\xdbB\x00\x00\x00H\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\x97\x02\x00\x00\x12\x00 \x00code/javaFile.javaUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\x8dQ\xcdN\xc30\f>7Oaz\xea\x00e\x0f0Mچ\x10\\\x90&v\xe0\x808\xb8\x9d\xd7E\xa4qH\xd2M\x03\xed\xddq\xdbMpA#\xa7$\xfe\xfel{\xacޱ&\xe0P\xeb\x8a\x1b\x8f\xee\xa0\xd1{k*L\x86\x9d\x8e\xa6\xf1\x96\xa2\x0f\xc6\xd5%s\x92\xdaD)\xf9\xe4\x90z\xd2P\xda\x04lh\xcf\xe1]w }=\xb9\b\xc16q\xc5nc\xea6\xd0\x05\u009eJ]\x1a\xb7\xd6\xe8\x9cD\xe8\x83\tC\x8dǳU\x0f]\x88\xe2\xfc'\xb5\x9a=SLw\xecR`k)\xa8ٽ\xc3\xd2\xd2\\<\xefN\x9e\x03з\xa5\x90\xa0\xb2\x18#<\xa1q\xf0\xa52\x95\xcd\x1e(=I\xab\"]\xc0\x0emKS\xc8\xc79\x8c\xa4$Ҟ]\xa4\x05\xaf\x0f\xa0\xb2\x93\xc2*u9`\xcb\r\x15\x02\xf9h%\xc0\x12\xa5\x83\"\xc8\xc3\x04ZO7h#\x8d\xceH'\xcd\xdd¿\xa0~ˎ`\xd4E\xcb\x02\xa568\xc8\x1f\xc9Z\x86\x1cn \xa23\xc9|R\x88\xfa|-:\xf1\x91\xd4\xf2+x!\xd8\x1bkAf\x9d\xb0Jp\xe0\x160\xfd\xc5\\vVEo\xd8\xf3!ne)\xf6\xa0\U000c962b\xec\xa8\x14\xc895\x1d\xbb]T\xb0c\xb3\x86F\x86W\f\x81_\xdf\x00C\x1d\xbb\xc0p:Ú~\xadH\x87\xd6\x15\xdd\xc0u?\xfbہ1\xe9\tGu\xfc\x06PK\a\b\xc1M\xc4=d\x01\x00\x00\x97\x02\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00<\x02\x00\x00\x16\x00 \x00code/javaScriptFile.jsUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\x9d\x91\xc1N\xc30\f\x86\xefy\n\xef\xb4V\xaa\xca}[\xb9\xb0\a@ \x10רu\xb5H]\x12\xc5\xceTQ\xe5\xddI\xdaR\x02B\x1c\xf0)\xb1\x9d\xff\xfbc\xab\xab5\x8e\xe1\te\xcb\x15L\x0f&\xde5j\x0e\xd0;s\x85\xbdK\x85\xfdQ\b\x81\xe3\xdc\xd9a/\xfd\xc0\xd0\x0e\x92\b^\x15y9\xa8w\xc9\xcah\xc0\x91Qw\x04\x9b\nL\x02b\xb4F\x13;߲q\x85u\xc6R\xb9\x16R\x90\xb7\xf8\x99>nY\xbe(\xaa\x89%#4Ys\x8a\xf1\x00\xda\x0fC\xb5%\xc3\xf2,\x88\x15\xb6\xc2Ϫ{\xb1]T\x88\xe2x{L\x80\n\xd2\xf19\xc9V@ZZ\xba\x18\xceͨ\xbe\xd8\xcd\xe8\xd9O=\x96?؋-\xe4Y\xa2\xf8^\xfb\xd5\xdbl,\xfbW\x00\x1c\b\x13'\xc7\xc0\xaei`s\xf9/l.\xf7\a>\x9f\x94\x8bˊ\xa3\xcfa\x0e\xd9;\rũS\xb7\xfb\xb7\x03L_{\xa8\xc7p\xbaK\xe9r\xd1\x10\xe1\x03PK\a\b\xa7M\xf9\xe9\xfd\x00\x00\x00<\x02\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\x87\x00\x00\x00\x12\x00 \x00code/kotlinFile.ktUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\xe3\xd2\xd7W\xf0H\xcd\xc9\xc9W\b\xcf/\xcaIQ\b(\xcaO/J\xcc\xe5\xe2J+\xcdS\xc8M\xcc\xcc\xd3H,J/V\xb0Rp,*J\xac\xb4\t.)\xca\xccK\xb7\xd3T\xa8\xe6R\x00\x82\xb2\xc4\x1c\x85\x82\xc4\xe2\xe2\xf2\xfc\xa2\x14\x05[\x05%\xe7\x8c\xd4\xe4\xec\xdcĢ\nEC#c%\xb0\x92\x02\xa0\x86\x92\x9c<\r%\xb0-:\x10k\x14\x15\x94\x14\xb4\xe1:5\xb9j\x01PK\a\b\x00:\x8cKu\x00\x00\x00\x87\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\x14\x00\x00\x00\x10\x00 \x00code/luaFile.luaUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00+(\xca\xcc+\xd1P\xf2H\xcd\xc9\xc9W\b\xcf/\xcaIQ\xd2\x04\x00PK\a\bK\x10_z\x16\x00\x00\x00\x14\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xce\x00\x00\x00\x15\x00 \x00code/objectivecFile.mUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00e\x8e1\v\xc20\x14\x84\xf7\xfc\x8a\xb3.m)\xea^\x15]\xc4ADpp\b\x19B\x1b\xda@\x9aW^S\x17\xf1\xbf\xdb\xd4\xc1\xc1\xe5q\xef>\uee25\xedz\xe2\x80\xed\x89F_\xeb`ɯ\x7fr\xd5\xee\x85\xf5\x01\x9d\xb6\x1eiT\x9a\x9b\xaa@E~\b\xa8Z\xcdȣ\xf5\x94*\x13/\x01\xe0z?\x8e\x81\xd88\xa3\as#r\xc8\xfbxw\x90\xf2\x9fi\xe7\xa8R\xb0\xde\x06U~\xe3\x17j\x90\x1e\x92\xb3\x99P\x81\a\xb1\xab\x17I6C97\xd5<\x8dQ\xe5\xf4#\x9al\xc2\xc8\x1e\x9b\x12\xe2\x8d\x0fPK\a\b8ޝ\xe6\x9a\x00\x00\x00\xce\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\x8c\x00\x00\x00\x10\x00 \x00code/perlFile.plUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00%\x8c1\x0f\xc2 \x14\x06w~\xc5W\xea\xa0\x13Q\xc7\xc6\xc9\xc5\xc5\xc4\xcd\xc5\x05\v*\x91>\xc8{\x90꿗\xc6咻\xe1\xfa\xceTas\x0fd\xb2\xe7\b\x05\xa8\x1e\xe7\xe4j\xf4\x82*\xdeA5B\n\x87\xb1\f\x7f\x99-S\xa0\xa74U\xd3\x17\xablE\xe6\xc4\x0e\a\xe8\xe3ˏ\xef\xc9\xf2\xa7\xdb\xee\xf6z\x99]8P\xc1\xa3\xd2XB\xa2\xf6\xcfKX듏1\xe1\x9a8\xba\x1b\xe9̀\x1fPK\a\b\x84\\s1{\x00\x00\x00\x8c\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xb6\v\x00\x00\x10\x00 \x00code/phpFile.phpUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\xbdV\xdbn\xe36\x10}\xe7WL\xd9\x14\x9b]\xac-\xdb\x01\x02Ėel\x8d\\^\xf6\x82MP\xa0O\x01-\x8d%\"\x92\xa8\x92T\x14\xb7\xe8\xbfwH[\xb6\xbcu\xda \x9b,\xfd \x89\x9c\x99s\xe6J\x87\xb3*\xab\x18\x04\xef^z1x\a\xdbu!s,E\x81c\xf8\x90\x14\xb2\xfc\x88e\xdd\xf7\xb8\x1d\x99K,Q\v\x8b\t4\xd2f0W\t\xce3\xa1S\x04\xb8\xef\x0f\xdd\xeflO\xfe\xcb\xd5\x17X\xd42O`0\nF\xa7\xc1h0\x18\x92\xc0K\xaf\x801Y\xc6y\x9d \x1c\xf3~\x10\xab\xa2P\xa5#\xcf\xdfN\xf6N\xaeP$\xa8\x0f\x9d\\(ew'̠1R\x95\xb7\xc6\nm\x8f\xddΑq\x01\xfaD\x01\x82)\xf0\xbd\x10\xf1\t;\xaa\x841\x8d҉;\x9cg\x18\xdf\x15B?\xfc4\x1c\x9d\xd0!c\xb1۹5\x18\xd7Z\xda\xd5\xf1\xc8\x19d\xb3(\xccl\x91G,̈\x16=\xac\xb49F\xbf*u\a\xd7Vi\f\x83\xf5\x0e\v\v\xb4\x02\\r\xa6\xfc\xf2\xfc\xd3\xf9\xd7\x0f7\x9f\xbfr\x88Ui\xb1\xb4S\xfe;\x9ak\xb5\xb4\x8d\xd0\xd8\xcdI\x9b\x12\xa8\x8d,SxC\xd9\xe8\xc7q\xf5\x86\xb7\x163k\xab\x1e\xfeQ\xcb\xfb)\xaf\xb4H\v\xd11Z\xaa^,\x88\xf8Ai|\xa8\xa4F\xd3\x11\x1f\xf0\b\x0e\bz\x13='\xa5U\xfe\x88\xf5`\xe3\xffB%+0v\x95\x93\x9b\v\x11ߥZ\xd5eBʹ\xd2c\xf8\xf9¯\t\xb4\xdf\x03\xbf&\xb0$\x9b\xbd\xa5(d\xbe\xa2\xda\xd5R\xe4\xef\xe1Fd\xaa\x10\xef\xe17ԉ(\xe9\xe5\n\xf3{\xb42\x16\xaff\xd8y\x12\x93o\xa8#\x06\xa1\x15\v\x979\xa07\xf7\xed\x9e\t܋\\\xa6\xe5\x94[Uq'4\x03WB\xb7יj\x8e\xdf\xc2\xcc\xcby\xd9\xc0&^7\xf0\xca\xf4X[\v\x83\x16 \xcc\x0e\xa3\x1cF\"\xa0\v\xa5\x8b}\xa0\xffDڹBH`\xe4\x9f8\x1dR\xcf'6\x9b\x9e\x0e~y\xa2\x83\x1eֵ\xd57\xc0-(\x1c\x00\x86-r\xe8\xc2\x0fK\x11S5\xf8\xe0\xf3(4\x85\xc8\xf3\xe8&\x93\x06\x92\x15\xf5\x83\x8c\x89\x9bEh\x84\x81t\x7f6\x85T\x87\x1a\x97S\xee\xaaq\x1c\x04M\xd3\xf4c\xea\x8d\xd8\xf7\x06\xbd\x16<\xda\xf5J\x18\x88(\f\xd6\xe6\xc3\xc0!G\x9d`\a\xae2}\xa1\xae\xfbu\x06Ծ,x\xf1\x81LF\x97u\x19[\x9a<݄\xb1\xbfX\x9a\xab\x85\xc8\xe1\xc8w\x87q\xd3\xc3\xcd\x0f\x1f}\x17\xb7\xb6kx7!>#\x8f\xb7\xd3\xc9\xc9\xe9\xe9\xd9\xd9\x04,>؞\xcf\xdb\x18\xe6\xde\xe3\t,h\x92\xa1\xeey\xe51\xa8\xda\x1a\xb4\xdb]_\x06c\x18\xf2M\x8a6\x10\xbe[\\\xa5\xd0Ѩ\xb2\xbbvj\xdb\xcb\v4(\xd3̎\xc9V\x9e\xf0ȏQi,\xe5\xcd\xf9\xec\xbaa\x1b}\xaa\x91Mq\xb4\x1e=\xc1\xa9\x16\xeb\xdfT\xdbr\xf8\x88\xc5\x02\xb5\xb9\xd42\xf1\xb3\xfbq'\x06]'\xd63\x81G\x1b\xf5-K\xd1a\xfa\xc4\xc0\xff?\xc7\xcf\xee\xe0\xd9\x14\xd7گ\xcb\xd0'\xce\xddT\xe69\f\xbd\xe2\xeb\x12\x9c\xd3$H\x95\x96\xf8\xec0\xee,\xbc.\xd3\xf3D\xd2eO\xf3\xed\xd9Lw\x16~\x10S\n\xcdws\x85\x1f\x15߹\xd0\xc9ͪ\xfa\x9eB\xa0\xbfu\xde·Dw\xf7W玞1\xf67c\xb3\xe8\x1fPK\a\b{(y\xa7\\\x03\x00\x00\xb6\v\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00 \x02\x00\x00\x12\x00 \x00code/plsqlFile.sqlUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\x9d\x90Ao\x82@\x14\x84\xef\xfc\x8a\xb9\xa1IM\xea\xa1'b\x13\x84G%\x85\xa5Y\x966=\x99-\xac\x91h\x17\xc5\xc5\xd4\x7f_\xb0\x045=4\xe9\x9e\xdefg\xbe\x99\xb7\x93\t\xe4Q\x96[\xf9\xb1U\xa8\xf4\xb6\xd4\n\xa5ƪl\xaf\xb6\xfa\x92\x9f\xbb\xa9m\xf9\xe4E.'\v\xc0ޜ\x96\x95^\xae\xa5.\x00\x96\xc5s⣇\xb1c\xcd\xe9)d\x9d \xa5\x88<\x81}#\xb5)\xcd\t!\x13ɍ+\xe0I\xdcF\x1c\x956U}\xea,\xedy[\x10'\xec\xea\xaahr\x83\x19lA\x8c\x85)\xb8\xeb=\x93\xb0{U\x90pd/\xbe+\bI0D8\xddk\x18܄<\xe2\x1ebA\fh\x17\xcc\xd7*\xdf\f\xf2\x9e\xd5s\x86\"m\xf1\xabֳ\xcb8\xc1\xb4\xb7\xfc\xdd\xd3\xe9\x95!K\x89\x8b\x9f\xddwM\x9d\xaf\xe5A-k\x95Wuqa\xbd\xbaQF)F\xb6PZ\x97\a\xd42\xdf(3\xe8\v\xfb\x0e\xe9{ڵ\x1c\x9f\xb9\x14\xa5\xf4/~\xd2\x18T+\x98\xeb\x98\xc3/:\xf3\xdbO<\x8f^\x12ǡp,b\xbe\xf3\rPK\a\b\xba\xce]#\x1d\x01\x00\x00 \x02\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\x98\x03\x00\x00\f\x00 \x00code/pom.xmlUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\xad\x92\xcfO\xc3 \x14\xc7\xcf\xeb_\xb1\xec^h\xa7\a\xb3`\x13\x0f3\x9aL\xb7X5^\x19}Nf\v\x04\xe8:\xff{\x1f\xad5\xadɌ\a\x8f\xef\xf1\xfd\xf1\x81\xc0\x8c\xd5{\x10~z\xacJ\xe5.goޛ\x05\xa5\x15?\x80\"\xdcp\xf1\x06D\xdb\x1dݬ\xef\xe89IH2딋\xa3\x93\xdf\xea\xa6iHs\xd6\xea\xe6I\x92җ\xbbU\x8eƊ\xc7R9ϕ\x00t9\xb9p\xedr\xa5\x05\xf7R\xab?\x94MO)\x8e\xae\xe8\x96q\xab#8ϲh:e\x95.\xa0|\x06\xeb\xb0 k\xcf\x18\x1d\xed\x82hgumn\x8b\f\x93\x88Е\xe1\xea\x03\xc3M);0F{A\x10s\xeb\xe5+\x17\x1eG'+SB쌕j\x17o\xb5\xf61\xda\x18\x1dH\x82\xe3\xf0U\x15\xc0\xd28\xbf\xbf\xda\xe47\xebGF\xfb}\x14M\x98\xe1\x16\x94Ϣ\xc9dD\xd3E\xbfZ^A\xa3\xed;\t\x1d\x03\x1cT\x0fq\x06\x1c\xf8\xccփ\x8d\xbb\xdc1\x12\xba\xfa\xea9I\xc9\x19yX\xae\x96W\xf9r@4a\xb4'\n7(\xc0\x80*@\t\t.\x1c~\xcf\x1f\xffO\xdc\xc0\xf6\a.\xa3\xa3\xbe\xc1\xd8\xe2 \x1f~Z\x03h\xe9\xc6n\x11~1\xd9ֲ,\x88ӵ\x15\xb0TB\x17X\x97==^\xc7\x17x\xc1\xdf4\xe3\x18\vFc\xbc\xda\x11]{S\xfbSQ'u]ܞ\x1f8\xe9\xdf8%h\x1cm\xc2M\xe8\xf0*Q\x9f\xec\xb3OPK\a\b\x10]\\p}\x01\x00\x00\x98\x03\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00S\x00\x00\x00\x12\x00 \x00code/pythonFile.pyUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00SV\b\xc9\xc8,V((\xcaO/J\xcc\x05ҙy%\xc5\n\x1e\xa999\xf9:\n\xe5\xf9E9)\x8a\\\\\x05\x89\xc5\xc5@v\x8a\x82\xad\x82R\x00\x90\xadhhdl\xa2\xc4\x05V\xac\xa1\x8e\xa2X]\x93\v\x00PK\a\bHv#zC\x00\x00\x00S\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xe6\x01\x00\x00\x0e\x00 \x00code/README.mdUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00MQ\xcbn\xdb0\x10\xbc\xeb+\xe6\x03XK\x96U\xb7\xd6-qZ\xf7\x91ƪ\x95\xe6\x12\xe4@\xd1k\x89)\xc5\x15(ڎ\xff\xbe\xab\x06\r\n\x10\xd8\xc1\xcc\xec.\a{\xadGڃ=\xba\x18\x87\xb1L\xd3\xd6\xc6\xee\xd8\xcc\f\xf7i\xcd\xde\xea\x1b\xab\xc7t\b\xfcL&\xf2\x0f\x1d\xcc\xd1Y\xcfIr\xdf\xd9\x11\x81\x06\x86a\x1f\xb5\xf5#\x0e\xd6\xd1\b\xeb#\xf9\xbd\f\x8d\x93t\xa2\x00\xed\x1c\x9c\xf6\xedQ\xb7\xa2k\xbf\a\xf9\xd6\xfa\t\x9f\xb4u\xbaq$]Xwd~\xf7:\xbcl=͒$/\xd2y\x96\xe6Y\xbe@\xf6\xb1\xccW\xe5b\xa1>d\x05\x1e\xe7O\xf8z\xf7y\v\\\xbdu\xf7\xd4s\xb8\x94ȋ\xf7E\x86_S\xa4\x7f\xd4<+r|rz\x98\xc8{\xdbS\x89,+\xe5-\x8a\xd9r\xbeZ\x16\xab%\x1ew4\xb2;Y\xdf>\xe1\x1d\xfe&\xab^\x13\xe3l\xe5\xf3\xa3\xd1\x1e\xb1#\x1c\xd89>\x8b\xef\xbf<Xם\x0e\x83\xc27I\xa3\xb0\xae\xaaWX\x9b`\x87\xa8p5Ћ\xc2CsGq*ot-\xbe\x87\xeb\xa5B\xf5E\xd0\xee\xd8\\\x04Rp\n\xdb\xe6\xd9\b\xbe\xad\x7f\xdeJ\xb9Ď\xbd\xc2&0\x9f\xc4R\x1b\xedd͆\x15\xbes\x94[\xc8JnX\xdav\xd5F\xe4\xb3=\xc8\xf4\x1b\x1d\xe2$\xf4\xbd\x9c\x16\xc9\x1fPK\a\b\xd0\xdf\xdd.]\x01\x00\x00\xe6\x01\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00)\x00\x00\x00\x14\x00 \x00code/rpgleFile.rpgleUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\xd3\xd2J+JM\xe5J).ȩTP\xf7H\xcd\xc9\xc9W(\xcf/\xcaIQT\xb7\xe6\xd2\xca\xcc\xcb)R\xb0U\xd0\xcaϳ\x06\x00PK\a\b\xa8)\xdc\xe3+\x00\x00\x00)\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x009\x00\x00\x00\x10\x00 \x00code/rubyFile.rbUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00+H,..\xcf/JQ\xb0UPr\xceHM\xce\xceM,\xaaP442V\xe2*(-)VP\xf2H\xcd\xc9\xc9W\b\xcf/\xcaIQPR\xd0V\x80h(J\x01\x00PK\a\b\x9dK\xc4l7\x00\x00\x009\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xa1\x00\x00\x00\x14\x00 \x00code/scalaFile.scalaUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00\xcbO\xcaJM.Q\xf0H\xcd\xc9\xc9W\xa8\xe6R\x00\x82\x94\xd44\x85\xdc\xc4\xcc<\x8dĢ\xf4b+\x05Ǣ\xa2\xc4\xca\xe8\xe0\x92\xa2̼\xf4XM\x05[\xa8*\x10(K,R(H,..\xcf/JQ\xb0R\x80\xa8\x01\xaaPr\xceHM\xce\xceM,\xaaP442V\x82\xab/\x00ʗ\xe4\xe4i(\x81\xad\xd3Q\x00\xea\xcbIQPRІ\x9b\xa2\tW\vf\xd4r\xd5\x02\x00PK\a\b\x81\xff\xb6\x81x\x00\x00\x00\xa1\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x006\x00\x00\x00\x14\x00 \x00code/swiftFile.swiftUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00+K,R(H,..\xcf/JQ\xb0UPr\xceHM\xce\xceM,\xaaP442V\xe2*(\xca\xcc+\xd1P\xf2H\xcd\xc9\xc9\xd7Q\b\xcf/\xcaIQT\xd2T\x00\x00PK\a\b\xa9\xb0\x8e\xf88\x00\x00\x006\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00\xe1\x00\x00\x00\x10\x00 \x00code/vb6File.basUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00U\x8e\xbd\n\xc2@\x10\x84\xfb<\xc5\xe4\nM@\x02jmaT\xb0I%h}&K<\xbc?n=\x8cooNB\xc0i\x96\x19f\x87o\x89=ę\xb4v+\xdc\\\xd0].\xe0\x83\xeb\x834P\x16W\xc5QjԒU[e\x8d\xeb\xa2&\xfc\xea\x19p\x89w4R٢\x1cM\xd2Q\x19x\xc9\xfcv\xa1\x03\xa6p\x0ev\x10\x87\a\xb5O#Ð\xaf7[1\x15\x1a\xeek7\x14\xff\x14\x10X̯%\x96\xe36{-?0\xc4,{\x82\xb3h\x9d\xf1\xf1E\x01\xdc\x06\"[\x8d{'\xdb%\xac,\xddD\xab\xe9\vPK\a\b\x1dR㮠\x00\x00\x00\xe1\x00\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00X\x01\x00\x00\x11\x00 \x00code/vbnetFile.vbUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00m\x90AK\x031\x10\x85\xef\xfe\x8ag.V\x94\x05\xf5\xec\xc5Z\xa8hK\xb1b\x8f\x127\xcf64\xc9,I\x16\xdd\x7f\xefn\\\x16\x0f\x1d\x06\xc2|\x13\xde̛'\xdfH\xcc\t\xdb.ez\\\x8c\xafM\xd0Xk\xcf\xd4\xe8\x9a\xc0\xd9JL\xeb\x88%\x9d\x93\x8fM\x94}Ծ\xc7%\x81m\xfb\x89\x95\xb6av9\x82!\x1e\xadG\xa3S\xfa\x96h&\xe8\x98'\x88{\xa8\xf9\x81\xf5\xd1\xeb\xf8s~s{\xa7\xa6os\tI\x1c\xab]\xb4\x99/6p\xa6\xca\xe8k\xec\xe8j\xf1D\x16\xe4\x03\xd1\xeb8\x03\xf9\xc2\xfbC\xb5^\xbc\xa9\xff\v\x9c\x10\xd9D\xa6\xdeZ\xe8pd7h\xd4\x12\xb2\r-\xab\xaa\x82\xc2մ\xdb)\x9dWj\xf3\xccn4Yڋ`\x8a\xf9\x02\x86\xe2\xefN\xc0/PK\a\b\x88\x88\xc1\xcc\xd0\x00\x00\x00X\x01\x00\x00PK\x03\x04\x14\x00\b\x00\b\x00\xc9-mW\x00\x00\x00\x00\x00\x00\x00\x00S\x00\x00\x00\x15\x00 \x00code/vbscriptFile.vbsUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00s\xc9\xccU(H,..\xcf/J\xe1\x821\x14l\x15\x94\x9c3R\x93\xb3s\x13\x8b*\x14\r\x8d\x8c\x95\xb8\x8aR\x8b\v\xf2\xf3\x8aS\xf5ʋ2KR5\x94<Rsr\xf2\x15\xc2\xf3\x8brR\x14\x15\x15\x94\x14\xb4a\xa6\xa4h\x02\x00PK\a\b5\x06R\xc5I\x00\x00\x00S\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xf8'\xe7n~\x01\x00\x00t\x03\x00\x00\x11\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x00\x00\x00\x00code/apexFile.clsUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x8a-\bV\\\x03\x00\x00\xe0\x0e\x00\x00\x10\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xdd\x01\x00\x00code/aspFile.aspUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xa0\xab=c\xb1\x00\x00\x00\xfc\x00\x00\x00\x12\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x97\x05\x00\x00code/cobolFile.cblUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x97\xf1\xa1d\xa3\x00\x00\x00\xd0\x00\x00\x00\x16\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xa8\x06\x00\x00code/cplusplusFile.cppUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xf9t\xba;\x8d\x00\x00\x00\xc9\x00\x00\x00\x12\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xaf\a\x00\x00code/csharpFile.csUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mWg\x0e\xfdZ\x83\x02\x00\x00\xed\x05\x00\x00\x12\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x9c\b\x00\x00code/dartFile.dartUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mWD\xc1\xd8s\xd2\x00\x00\x00\x13\x01\x00\x00\x0f\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x7f\v\x00\x00code/DockerfileUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mWm\xe9A\x8bj\x00\x00\x00q\x00\x00\x00\x0e\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xae\f\x00\x00code/goFile.goUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mWӏ\xea\xdbB\x00\x00\x00H\x00\x00\x00\x16\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81t\r\x00\x00code/groovyFile.groovyUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xc1M\xc4=d\x01\x00\x00\x97\x02\x00\x00\x12\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x1a\x0e\x00\x00code/javaFile.javaUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xa7M\xf9\xe9\xfd\x00\x00\x00<\x02\x00\x00\x16\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xde\x0f\x00\x00code/javaScriptFile.jsUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x00:\x8cKu\x00\x00\x00\x87\x00\x00\x00\x12\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81?\x11\x00\x00code/kotlinFile.ktUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mWK\x10_z\x16\x00\x00\x00\x14\x00\x00\x00\x10\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x14\x12\x00\x00code/luaFile.luaUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW8ޝ\xe6\x9a\x00\x00\x00\xce\x00\x00\x00\x15\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x88\x12\x00\x00code/objectivecFile.mUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x84\\s1{\x00\x00\x00\x8c\x00\x00\x00\x10\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x85\x13\x00\x00code/perlFile.plUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW{(y\xa7\\\x03\x00\x00\xb6\v\x00\x00\x10\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81^\x14\x00\x00code/phpFile.phpUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xba\xce]#\x1d\x01\x00\x00 \x02\x00\x00\x12\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x18\x18\x00\x00code/plsqlFile.sqlUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x10]\\p}\x01\x00\x00\x98\x03\x00\x00\f\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x95\x19\x00\x00code/pom.xmlUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mWHv#zC\x00\x00\x00S\x00\x00\x00\x12\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81l\x1b\x00\x00code/pythonFile.pyUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xd0\xdf\xdd.]\x01\x00\x00\xe6\x01\x00\x00\x0e\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x0f\x1c\x00\x00code/README.mdUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xa8)\xdc\xe3+\x00\x00\x00)\x00\x00\x00\x14\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xc8\x1d\x00\x00code/rpgleFile.rpgleUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x9dK\xc4l7\x00\x00\x009\x00\x00\x00\x10\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81U\x1e\x00\x00code/rubyFile.rbUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x81\xff\xb6\x81x\x00\x00\x00\xa1\x00\x00\x00\x14\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xea\x1e\x00\x00code/scalaFile.scalaUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\xa9\xb0\x8e\xf88\x00\x00\x006\x00\x00\x00\x14\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\xc4\x1f\x00\x00code/swiftFile.swiftUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x1dR㮠\x00\x00\x00\xe1\x00\x00\x00\x10\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81^ \x00\x00code/vb6File.basUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW\x88\x88\xc1\xcc\xd0\x00\x00\x00X\x01\x00\x00\x11\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\\!\x00\x00code/vbnetFile.vbUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\b\x00\b\x00\xc9-mW5\x06R\xc5I\x00\x00\x00S\x00\x00\x00\x15\x00 \x00\x00\x00\x00\x00\x00\x00\x00\x00\xb6\x81\x8b\"\x00\x00code/vbscriptFile.vbsUT\r\x00\a+\xb8Qe\x00\x00\x00\x00\xe7\x8eCfux\v\x00\x01\x04\x00\x00\x00\x00\x04\x00\x00\x00\x00PK\x05\x06\x00\x00\x00\x00\x1b\x00\x1b\x00\x19\n\x00\x007#\x00\x00\x00\x00")
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+var logger *logrus.Logger
+
+const labelTag = "seed-run"
+
+// labelPrefix is put in front of the names of created top-level groups and users, which cannot be tagged
+func labelPrefix(label string) string {
+	return label + "."
+}
+
+func main() {
+	logger = logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	logger.Info("Starting")
+	logger.Info("The purpose of this tool is to seed a test tenant with groups, applications, projects, scans and users from a YAML spec. Do not run this against a production tenant.")
+
+	SpecFile := flag.String("spec", "seed.yaml", "YAML file describing the groups, applications, projects and users to create")
+	Label := flag.String("label", "", "Run label added to every created object, default: the label from the spec, or seed-<timestamp>")
+	Cleanup := flag.String("cleanup", "", "Instead of seeding, delete everything created by the run with this label")
+	Delay := flag.Int("delay", 0, "Delay in milliseconds between created objects")
+	Update := flag.Bool("update", false, "Create (or with -cleanup: delete) the objects, otherwise only inform")
+
+	httpClient := &http.Client{}
+	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
+	if err != nil {
+		logger.Fatalf("Error creating client: %s", err)
+	}
+	logger.Infof("Connected with %v", cx1client.String())
+
+	if *Update {
+		logger.Warn("The 'update' flag is set - changes will be applied")
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	if *Cleanup != "" {
+		cleanup(cx1client, *Cleanup, *Delay, *Update)
+	} else {
+		spec, err := ReadSpec(*SpecFile)
+		if err != nil {
+			logger.Fatalf("Failed to read spec %v: %s", *SpecFile, err)
+		}
+
+		if *Label != "" {
+			spec.Label = *Label
+		} else if spec.Label == "" {
+			spec.Label = "seed-" + time.Now().Format("20060102-150405")
+		}
+
+		logger.Infof("Seeding run %v will create %v", spec.Label, spec.Summary())
+		seed(cx1client, spec, *Delay, *Update)
+	}
+
+	if !*Update {
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+)
+
+// ManifestEntry records one object created by a seeding run, so that cleanup removes exactly what was created
+type ManifestEntry struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Manifest struct {
+	file *os.File
+}
+
+func manifestFilename(label string) string {
+	return label + ".jsonl"
+}
+
+func OpenManifest(label string) (*Manifest, error) {
+	file, err := os.OpenFile(manifestFilename(label), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Manifest{file: file}, nil
+}
+
+func (m *Manifest) Record(objectType, id, name string) error {
+	data, err := json.Marshal(ManifestEntry{objectType, id, name})
+	if err != nil {
+		return err
+	}
+
+	_, err = m.file.Write(append(data, '\n'))
+	return err
+}
+
+func (m *Manifest) Close() error {
+	return m.file.Close()
+}
+
+func ReadManifest(label string) ([]ManifestEntry, error) {
+	entries := []ManifestEntry{}
+
+	file, err := os.Open(manifestFilename(label))
+	if err != nil {
+		return entries, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry ManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return entries, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
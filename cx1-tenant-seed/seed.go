@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+type Seeder struct {
+	cx1client *Cx1ClientGo.Cx1Client
+	spec      Spec
+	manifest  *Manifest
+	delay     time.Duration
+	update    bool
+
+	groups      map[string]string // group path (without leading /) -> ID, empty ID for groups that would be created in a dry run
+	apps        map[string]*Cx1ClientGo.Application
+	changedApps []string
+	roles       map[string]Cx1ClientGo.Role
+	created     map[string]int
+	failed      int
+}
+
+func seed(cx1client *Cx1ClientGo.Cx1Client, spec Spec, delay int, update bool) {
+	s := Seeder{
+		cx1client: cx1client,
+		spec:      spec,
+		delay:     time.Duration(delay) * time.Millisecond,
+		update:    update,
+		groups:    make(map[string]string),
+		apps:      make(map[string]*Cx1ClientGo.Application),
+		roles:     make(map[string]Cx1ClientGo.Role),
+		created:   make(map[string]int),
+	}
+
+	if update {
+		var err error
+		if s.manifest, err = OpenManifest(spec.Label); err != nil {
+			logger.Fatalf("Failed to open manifest %v: %s", manifestFilename(spec.Label), err)
+		}
+		defer s.manifest.Close()
+		logger.Infof("Created objects will be recorded in %v - use -cleanup %v to remove them", manifestFilename(spec.Label), spec.Label)
+	}
+
+	s.seedGroups()
+	s.seedApplications()
+	s.seedProjects()
+	s.seedUsers()
+
+	verb := "Created"
+	if !update {
+		verb = "Would create"
+	}
+	logger.Infof("%v %d groups, %d applications, %d projects, %d scans, %d users for run %v - %d failures", verb,
+		s.created["group"], s.created["application"], s.created["project"], s.created["scan"], s.created["user"], spec.Label, s.failed)
+}
+
+// record adds the created object to the manifest, and logs the outcome of the creation
+func (s *Seeder) record(progress, objectType, id, name string, err error) bool {
+	if err != nil {
+		logger.Errorf("%v Failed to create %v %v: %s", progress, objectType, name, err)
+		s.failed++
+		return false
+	}
+
+	s.created[objectType]++
+	if !s.update {
+		logger.Debugf("%v Would create %v %v", progress, objectType, name)
+		return true
+	}
+
+	logger.Infof("%v Created %v %v", progress, objectType, name)
+	if err := s.manifest.Record(objectType, id, name); err != nil {
+		logger.Fatalf("Failed to write %v %v to manifest: %s", objectType, name, err)
+	}
+	time.Sleep(s.delay)
+	return true
+}
+
+func (s *Seeder) labelTags(tags map[string]string) map[string]string {
+	labelled := make(map[string]string)
+	maps.Copy(labelled, tags)
+	labelled[labelTag] = s.spec.Label
+	return labelled
+}
+
+func (s *Seeder) seedGroups() {
+	for _, gs := range s.spec.Groups {
+		logger.Infof("Creating %d groups %v with %d child groups each", gs.Count, gs.Name, len(gs.Children))
+		for i := 1; i <= gs.Count; i++ {
+			name := expandName(gs.Name, i)
+			groupName := labelPrefix(s.spec.Label) + name
+			progress := fmt.Sprintf("[#%d/%d]", i, gs.Count)
+
+			var group Cx1ClientGo.Group
+			var err error
+			if s.update {
+				group, err = s.cx1client.CreateGroup(groupName)
+			}
+			if !s.record(progress, "group", group.GroupID, groupName, err) {
+				continue
+			}
+			s.groups[name] = group.GroupID
+
+			for _, childName := range gs.Children {
+				var child Cx1ClientGo.Group
+				if s.update {
+					child, err = s.cx1client.CreateChildGroup(&group, childName)
+				}
+				if s.record(progress, "group", child.GroupID, groupName+"/"+childName, err) {
+					s.groups[name+"/"+childName] = child.GroupID
+				}
+			}
+		}
+	}
+}
+
+func (s *Seeder) seedApplications() {
+	for _, as := range s.spec.Applications {
+		logger.Infof("Creating %d applications %v", as.Count, as.Name)
+		for i := 1; i <= as.Count; i++ {
+			name := expandName(as.Name, i)
+			progress := fmt.Sprintf("[#%d/%d]", i, as.Count)
+			app := Cx1ClientGo.Application{Name: name}
+
+			var err error
+			if s.update {
+				app, err = s.cx1client.CreateApplication(name)
+			}
+			if !s.record(progress, "application", app.ApplicationID, name, err) {
+				continue
+			}
+			s.apps[name] = &app
+
+			// CreateApplication only takes a name, so the tags are set in a second call - until then the application is only in the manifest
+			app.Description = as.Description
+			app.Criticality = as.Criticality
+			app.Tags = s.labelTags(as.Tags)
+			if s.update {
+				if err = s.cx1client.UpdateApplication(&app); err != nil {
+					logger.Errorf("%v Failed to set the description, criticality and tags of application %v, it can only be cleaned up through the manifest: %s", progress, app.String(), err)
+					s.failed++
+				}
+			}
+		}
+	}
+}
+
+func (s *Seeder) seedProjects() {
+	for _, ps := range s.spec.Projects {
+		logger.Infof("Creating %d projects %v", ps.Count, ps.Name)
+		for i := 1; i <= ps.Count; i++ {
+			name := expandName(ps.Name, i)
+			progress := fmt.Sprintf("[#%d/%d]", i, ps.Count)
+
+			groupIDs := []string{}
+			for _, ref := range ps.Groups {
+				id, err := s.groupID(s.spec.ResolveGroup(ref, i))
+				if err != nil {
+					logger.Errorf("%v Project %v: %s", progress, name, err)
+				} else if id != "" {
+					groupIDs = append(groupIDs, id)
+				}
+			}
+
+			project := Cx1ClientGo.Project{Name: name}
+			var err error
+			if s.update {
+				project, err = s.cx1client.CreateProject(name, groupIDs, s.labelTags(ps.Tags))
+			}
+			if !s.record(progress, "project", project.ProjectID, name, err) {
+				continue
+			}
+
+			for _, ref := range ps.Applications {
+				app, err := s.application(s.spec.ResolveApplication(ref, i))
+				if err != nil {
+					logger.Errorf("%v Project %v: %s", progress, name, err)
+					continue
+				}
+				app.AssignProject(&project)
+				if !slices.Contains(s.changedApps, app.Name) {
+					s.changedApps = append(s.changedApps, app.Name)
+				}
+			}
+
+			if ps.Scan {
+				var scan Cx1ClientGo.Scan
+				if s.update {
+					scan, err = s.startScan(&project, ps.Branch)
+				}
+				s.record(progress, "scan", scan.ScanID, fmt.Sprintf("%v (%v)", name, ps.Branch), err)
+			}
+		}
+	}
+
+	for i, name := range s.changedApps {
+		app := s.apps[name]
+		rule := app.GetRuleByType("project.name.in")
+		count := 0
+		if rule != nil {
+			count = len(strings.Split(rule.Value, ";"))
+		}
+
+		if !s.update {
+			logger.Infof("[#%d/%d] Would assign %d projects to application %v", i+1, len(s.changedApps), count, name)
+		} else if err := s.cx1client.UpdateApplication(app); err != nil {
+			logger.Errorf("[#%d/%d] Failed to assign projects to application %v: %s", i+1, len(s.changedApps), app.String(), err)
+			s.failed++
+		} else {
+			logger.Infof("[#%d/%d] Assigned %d projects to application %v", i+1, len(s.changedApps), count, app.String())
+		}
+	}
+}
+
+func (s *Seeder) seedUsers() {
+	for _, us := range s.spec.Users {
+		logger.Infof("Creating %d users %v", us.Count, us.Name)
+		for i := 1; i <= us.Count; i++ {
+			name := labelPrefix(s.spec.Label) + expandName(us.Name, i)
+			progress := fmt.Sprintf("[#%d/%d]", i, us.Count)
+
+			user := Cx1ClientGo.User{
+				Enabled:   true,
+				UserName:  name,
+				Email:     fmt.Sprintf("%v@%v", name, us.EmailDomain),
+				FirstName: "Seed",
+				LastName:  s.spec.Label,
+			}
+
+			var err error
+			if s.update {
+				user, err = s.cx1client.CreateUser(user)
+			}
+			if !s.record(progress, "user", user.UserID, name, err) {
+				continue
+			}
+
+			for _, ref := range us.Groups {
+				path := s.spec.ResolveGroup(ref, i)
+				id, err := s.groupID(path)
+				if err != nil {
+					logger.Errorf("%v User %v: %s", progress, name, err)
+				} else if s.update {
+					if err = s.cx1client.AssignUserToGroupByID(&user, id); err != nil {
+						logger.Errorf("%v Failed to add user %v to group %v: %s", progress, name, path, err)
+					}
+				}
+			}
+
+			roles := []Cx1ClientGo.Role{}
+			for _, roleName := range us.Roles {
+				role, err := s.role(roleName)
+				if err != nil {
+					logger.Errorf("%v User %v: %s", progress, name, err)
+				} else {
+					roles = append(roles, role)
+				}
+			}
+			if len(roles) > 0 && s.update {
+				if err = s.cx1client.AddUserRoles(&user, &roles); err != nil {
+					logger.Errorf("%v Failed to add roles to user %v: %s", progress, name, err)
+				}
+			}
+		}
+	}
+}
+
+// startScan uploads the embedded sample code and starts a SAST scan, without waiting for it to complete
+func (s *Seeder) startScan(project *Cx1ClientGo.Project, branch string) (Cx1ClientGo.Scan, error) {
+	uploadURL, err := s.cx1client.UploadBytes(&resourceCodeZip)
+	if err != nil {
+		return Cx1ClientGo.Scan{}, err
+	}
+
+	sastScanConfig := Cx1ClientGo.ScanConfiguration{
+		ScanType: "sast",
+	}
+	return s.cx1client.ScanProjectZipByID(project.ProjectID, uploadURL, branch, []Cx1ClientGo.ScanConfiguration{sastScanConfig}, map[string]string{labelTag: s.spec.Label})
+}
+
+// groupID returns the ID of a group created by this run, or looks up an existing group by path
+func (s *Seeder) groupID(path string) (string, error) {
+	if id, ok := s.groups[path]; ok {
+		return id, nil
+	}
+
+	group, err := s.cx1client.GetGroupByPath("/" + path)
+	if err != nil {
+		return "", fmt.Errorf("group %v not found: %s", path, err)
+	}
+	s.groups[path] = group.GroupID
+	return group.GroupID, nil
+}
+
+func (s *Seeder) application(name string) (*Cx1ClientGo.Application, error) {
+	if app, ok := s.apps[name]; ok {
+		return app, nil
+	}
+
+	app, err := s.cx1client.GetApplicationByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("application %v not found: %s", name, err)
+	}
+	s.apps[name] = &app
+	return &app, nil
+}
+
+func (s *Seeder) role(name string) (Cx1ClientGo.Role, error) {
+	if role, ok := s.roles[name]; ok {
+		return role, nil
+	}
+
+	role, err := s.cx1client.GetRoleByName(strings.TrimPrefix(name, "ast-app."))
+	if err != nil {
+		return role, fmt.Errorf("role %v not found: %s", name, err)
+	}
+	s.roles[name] = role
+	return role, nil
+}
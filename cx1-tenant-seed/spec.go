@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Names in the spec may contain {n}, which is replaced by the 4-digit index of each generated object (eg: team-{n} -> team-0001 ... team-0020).
+// References to groups or applications using a {n} pattern are spread round-robin over the generated objects, other references must name existing objects.
+type GroupSpec struct {
+	Name     string   `yaml:"name"`
+	Count    int      `yaml:"count"`
+	Children []string `yaml:"children"`
+}
+
+type ApplicationSpec struct {
+	Name        string            `yaml:"name"`
+	Count       int               `yaml:"count"`
+	Description string            `yaml:"description"`
+	Criticality uint              `yaml:"criticality"`
+	Tags        map[string]string `yaml:"tags"`
+}
+
+type ProjectSpec struct {
+	Name         string            `yaml:"name"`
+	Count        int               `yaml:"count"`
+	Tags         map[string]string `yaml:"tags"`
+	Groups       []string          `yaml:"groups"`
+	Applications []string          `yaml:"applications"`
+	Scan         bool              `yaml:"scan"`
+	Branch       string            `yaml:"branch"`
+}
+
+type UserSpec struct {
+	Name        string   `yaml:"name"`
+	Count       int      `yaml:"count"`
+	EmailDomain string   `yaml:"email-domain"`
+	Groups      []string `yaml:"groups"`
+	Roles       []string `yaml:"roles"`
+}
+
+type Spec struct {
+	Label        string            `yaml:"label"`
+	Groups       []GroupSpec       `yaml:"groups"`
+	Applications []ApplicationSpec `yaml:"applications"`
+	Projects     []ProjectSpec     `yaml:"projects"`
+	Users        []UserSpec        `yaml:"users"`
+
+	// name pattern -> count, used to resolve references
+	groupCounts map[string]int
+	appCounts   map[string]int
+}
+
+func ReadSpec(filename string) (Spec, error) {
+	var spec Spec
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return spec, err
+	}
+	if err = yaml.Unmarshal(data, &spec); err != nil {
+		return spec, err
+	}
+
+	spec.groupCounts = make(map[string]int)
+	for i := range spec.Groups {
+		if spec.Groups[i].Count < 1 {
+			spec.Groups[i].Count = 1
+		}
+		spec.groupCounts[spec.Groups[i].Name] = spec.Groups[i].Count
+	}
+
+	spec.appCounts = make(map[string]int)
+	for i := range spec.Applications {
+		if spec.Applications[i].Count < 1 {
+			spec.Applications[i].Count = 1
+		}
+		if spec.Applications[i].Criticality == 0 {
+			spec.Applications[i].Criticality = 3
+		}
+		spec.appCounts[spec.Applications[i].Name] = spec.Applications[i].Count
+	}
+
+	for i := range spec.Projects {
+		if spec.Projects[i].Count < 1 {
+			spec.Projects[i].Count = 1
+		}
+		if spec.Projects[i].Branch == "" {
+			spec.Projects[i].Branch = "main"
+		}
+	}
+
+	for i := range spec.Users {
+		if spec.Users[i].Count < 1 {
+			spec.Users[i].Count = 1
+		}
+		if spec.Users[i].EmailDomain == "" {
+			spec.Users[i].EmailDomain = "example.com"
+		}
+	}
+
+	return spec, nil
+}
+
+func expandName(pattern string, index int) string {
+	return strings.ReplaceAll(pattern, "{n}", fmt.Sprintf("%04d", index))
+}
+
+func resolveRef(ref string, index int, counts map[string]int) string {
+	if count, ok := counts[ref]; ok {
+		return expandName(ref, (index-1)%count+1)
+	}
+	return ref
+}
+
+// ResolveGroup turns a group reference (a name or parent/child path, optionally with a {n} pattern) into the group path for the index-th object
+func (s Spec) ResolveGroup(ref string, index int) string {
+	top, child, hasChild := strings.Cut(ref, "/")
+	path := resolveRef(top, index, s.groupCounts)
+	if hasChild {
+		path += "/" + child
+	}
+	return path
+}
+
+func (s Spec) ResolveApplication(ref string, index int) string {
+	return resolveRef(ref, index, s.appCounts)
+}
+
+func (s Spec) Summary() string {
+	groups, apps, projects, scans, users := 0, 0, 0, 0, 0
+	for _, g := range s.Groups {
+		groups += g.Count * (1 + len(g.Children))
+	}
+	for _, a := range s.Applications {
+		apps += a.Count
+	}
+	for _, p := range s.Projects {
+		projects += p.Count
+		if p.Scan {
+			scans += p.Count
+		}
+	}
+	for _, u := range s.Users {
+		users += u.Count
+	}
+	return fmt.Sprintf("%d groups, %d applications, %d projects, %d scans, %d users", groups, apps, projects, scans, users)
+}
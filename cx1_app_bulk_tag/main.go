@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/bulk"
+	"github.com/cxpsemea/cx1_go_scripts/bulk/selection"
 	"github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
@@ -27,13 +29,18 @@ func main() {
 	logger.SetOutput(os.Stdout)
 
 	LogLevel := flag.String("log", "INFO", "Log level: TRACE, DEBUG, INFO, WARNING, ERROR, FATAL")
-	AppsFile := flag.String("apps", "appIds.txt", "File containing 1 application ID per line")
+	AppsFile := flag.String("apps", "appIds.txt", "File containing 1 application ID per line - not used when selecting with filters")
 	AppTag := flag.String("tag", "cx336-ui-fix", "Tag to add and remove")
 	Update := flag.Bool("update", false, "Apply the change or just inform")
 	AddOnly := flag.Bool("add", false, "Only add the tag, do not remove")
-	Delay := flag.Int("delay", 5000, "Delay in milliseconds (per project in the application) between applications")
+	Delay := flag.Int("delay", 5000, "Initial delay in milliseconds (per project in the application) between applications")
+	MinDelay := flag.Int("min-delay", 500, "Minimum delay in milliseconds (per project in the application) when pacing adaptively")
+	MaxDelay := flag.Int("max-delay", 60000, "Maximum delay in milliseconds (per project in the application) when pacing adaptively")
+	TargetLatency := flag.Int("target-latency", 2000, "Slow down when API calls take longer than this on average, in milliseconds")
+	Adaptive := flag.Bool("adaptive", true, "Adapt the delay to API response times, throttling (429) and server errors (5xx) - set to false for a fixed -delay")
 	RunAll := flag.Bool("all", false, "Run all updates in sequence without pausing between each application")
 	RemoveOnly := flag.Bool("remove", false, "Only remove the tag, do not add")
+	Select := selection.AddFlags(false)
 
 	logger.Info("Starting")
 	client := &http.Client{}
@@ -44,6 +51,8 @@ func main() {
 		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 		client.Transport = transport
 	}
+	pacer := bulk.NewPacer(client.Transport, logger)
+	client.Transport = pacer
 
 	cx1client, err := Cx1ClientGo.NewClient(client, logger)
 	if err != nil {
@@ -75,6 +84,27 @@ func main() {
 		logger.Info("Log level set to default: INFO")
 	}
 
+	err = pacer.Configure(time.Duration(*Delay)*time.Millisecond, time.Duration(*MinDelay)*time.Millisecond, time.Duration(*MaxDelay)*time.Millisecond, time.Duration(*TargetLatency)*time.Millisecond, *Adaptive)
+	if err != nil {
+		logger.Fatalf("Invalid delay settings: %s", err)
+	}
+
+	if Select.List || Select.Active() {
+		logger.Infof("Selecting applications: %v", Select.String())
+	}
+
+	if Select.List {
+		selected, err := Select.SelectApplications(cx1client)
+		if err != nil {
+			logger.Fatalf("Failed to select applications: %s", err)
+		}
+		for _, s := range selected {
+			logger.Infof("%v (created: %v, tags: %v)", s.String(), s.CreatedAt, s.Tags)
+		}
+		logger.Infof("Selected %d applications", len(selected))
+		return
+	}
+
 	if *Update {
 		logger.Warnf("This will update project tags by adding and removing tag: %v", *AppTag)
 	} else {
@@ -82,7 +112,15 @@ func main() {
 	}
 	AppIDs := []string{}
 
-	{
+	if Select.Active() {
+		selected, err := Select.SelectApplications(cx1client)
+		if err != nil {
+			logger.Fatalf("Failed to select applications: %s", err)
+		}
+		for _, s := range selected {
+			AppIDs = append(AppIDs, s.ApplicationID)
+		}
+	} else {
 		file, err := os.Open(*AppsFile)
 		if err != nil {
 			logger.Fatalf("Failed to open file: %v", err)
@@ -114,9 +152,10 @@ AppLoop:
 			progress := fmt.Sprintf("[#%d/%d] ", i+1, totalCount)
 
 			if *Update {
-				totalWait := *Delay * len(*app.ProjectIds)
+				projectCount := len(*app.ProjectIds)
+				delay := pacer.Delay()
 
-				logger.Infof("%vApplication %v has %d projects - will wait for %d * %d = %d ms per update", progress, app.String(), len(*app.ProjectIds), *Delay, len(*app.ProjectIds), totalWait)
+				logger.Infof("%vApplication %v has %d projects - will wait for %v * %d = %v per update", progress, app.String(), projectCount, delay, projectCount, delay*time.Duration(projectCount))
 
 				if !*RemoveOnly {
 					app.Tags[*AppTag] = ""
@@ -126,7 +165,7 @@ AppLoop:
 					} else {
 						logger.Infof("%vSuccessfully updated application %v (added tag)", progress, app.String())
 					}
-					time.Sleep(time.Duration(totalWait) * time.Millisecond)
+					pacer.Wait(projectCount)
 				}
 
 				if !*AddOnly {
@@ -137,15 +176,17 @@ AppLoop:
 					} else {
 						logger.Infof("%vSuccessfully updated application %v (removed tag)", progress, app.String())
 					}
-					time.Sleep(time.Duration(totalWait) * time.Millisecond)
+					pacer.Wait(projectCount)
 				}
 
+				pacer.Done()
+
 				retryInput := true
 				for retryInput {
 					retryInput = false
 
 					if !*RunAll && i < totalCount-1 {
-						logger.Infof("Continue? [yes/no/all or d=# to adjust delay]: ")
+						logger.Infof("Current delay is %v per project. Continue? [yes/no/all or d=# to adjust delay]: ", pacer.Delay())
 						scanner := bufio.NewScanner(os.Stdin)
 						if scanner.Scan() {
 							input := strings.ToLower(strings.TrimSpace(scanner.Text()))
@@ -155,15 +196,20 @@ AppLoop:
 							} else if input == "a" || input == "all" {
 								logger.Info("Continuing for all subsequent items.")
 								*RunAll = true
-							} else if input[0] == 'd' && input[1] == '=' {
+							} else if strings.HasPrefix(input, "d=") {
 								new_delay := input[2:]
 								new_delay_int, err := strconv.Atoi(new_delay)
 								if err != nil {
 									retryInput = true
 									logger.Errorf("Failed to parse new delay value %v: %v", new_delay, err)
 								} else {
-									logger.Infof("Updating delay from %d to %d", *Delay, new_delay_int)
-									*Delay = new_delay_int
+									old_delay := pacer.Delay()
+									requested := time.Duration(new_delay_int) * time.Millisecond
+									if applied := pacer.SetDelay(requested); applied != requested {
+										logger.Warnf("Updating delay from %v to %v - %v is outside of the -min-delay %v and -max-delay %v limits", old_delay, applied, requested, pacer.MinDelay, pacer.MaxDelay)
+									} else {
+										logger.Infof("Updating delay from %v to %v", old_delay, applied)
+									}
 								}
 							}
 						}
@@ -175,5 +221,9 @@ AppLoop:
 		}
 	}
 
-	logger.Infof("Done")
+	if *Update {
+		logger.Infof("Done - %v", pacer.Report())
+	} else {
+		logger.Infof("Done")
+	}
 }
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// Entity holds the editable state of a project or application, so that the state before and after the operations can be compared
+type Entity struct {
+	Type        string            `json:"type"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Tags        map[string]string `json:"tags"`
+	Criticality uint              `json:"criticality"`
+	Groups      []string          `json:"groups,omitempty"`
+	MainBranch  string            `json:"mainBranch,omitempty"`
+
+	project *Cx1ClientGo.Project
+	app     *Cx1ClientGo.Application
+}
+
+// Editor loads, edits and saves entities, and resolves group paths to IDs
+type Editor struct {
+	cx1client  *Cx1ClientGo.Cx1Client
+	entityType string
+	groupIDs   map[string]string // path or name -> ID
+	groupNames map[string]string // ID -> path
+}
+
+func NewEditor(cx1client *Cx1ClientGo.Cx1Client, entityType string) *Editor {
+	return &Editor{
+		cx1client:  cx1client,
+		entityType: entityType,
+		groupIDs:   make(map[string]string),
+		groupNames: make(map[string]string),
+	}
+}
+
+func (e Entity) String() string {
+	return fmt.Sprintf("%v [%v] %v", e.Type, Cx1ClientGo.ShortenGUID(e.ID), e.Name)
+}
+
+func (e Entity) Clone() Entity {
+	clone := e
+	clone.Tags = maps.Clone(e.Tags)
+	if clone.Tags == nil {
+		clone.Tags = make(map[string]string)
+	}
+	clone.Groups = slices.Clone(e.Groups)
+	return clone
+}
+
+func (ed *Editor) Load(id string) (Entity, error) {
+	if ed.entityType == "application" {
+		app, err := ed.cx1client.GetApplicationByID(id)
+		if err != nil {
+			return Entity{}, err
+		}
+		return ed.FromApplication(&app), nil
+	}
+
+	project, err := ed.cx1client.GetProjectByID(id)
+	if err != nil {
+		return Entity{}, err
+	}
+	return ed.FromProject(&project), nil
+}
+
+func (ed *Editor) FromProject(project *Cx1ClientGo.Project) Entity {
+	return Entity{
+		Type:        "project",
+		ID:          project.ProjectID,
+		Name:        project.Name,
+		Tags:        maps.Clone(project.Tags),
+		Criticality: project.Criticality,
+		Groups:      slices.Clone(project.Groups),
+		MainBranch:  project.MainBranch,
+		project:     project,
+	}
+}
+
+func (ed *Editor) FromApplication(app *Cx1ClientGo.Application) Entity {
+	return Entity{
+		Type:        "application",
+		ID:          app.ApplicationID,
+		Name:        app.Name,
+		Tags:        maps.Clone(app.Tags),
+		Criticality: app.Criticality,
+		app:         app,
+	}
+}
+
+// ValidateOperations checks that the operations can be applied to the entity type, and resolves the groups used
+func (ed *Editor) ValidateOperations(ops []Operation) error {
+	for _, op := range ops {
+		switch op.Action {
+		case "add-group", "remove-group", "set-main-branch":
+			if ed.entityType != "project" {
+				return fmt.Errorf("operation %v can only be applied to projects", op.String())
+			}
+		}
+
+		if op.Action == "add-group" || op.Action == "remove-group" {
+			if _, err := ed.groupID(op.Value); err != nil {
+				return fmt.Errorf("operation %v: %s", op.String(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Apply returns a copy of the entity with all operations applied in order
+func (ed *Editor) Apply(before Entity, ops []Operation) Entity {
+	after := before.Clone()
+
+	for _, op := range ops {
+		switch op.Action {
+		case "add-tag":
+			if _, ok := after.Tags[op.Key]; !ok {
+				after.Tags[op.Key] = op.Value
+			}
+		case "set-tag":
+			after.Tags[op.Key] = op.Value
+		case "remove-tag":
+			delete(after.Tags, op.Key)
+		case "rename-tag":
+			if value, ok := after.Tags[op.Key]; ok {
+				delete(after.Tags, op.Key)
+				after.Tags[op.Value] = value
+			}
+		case "set-criticality":
+			c, _ := strconv.Atoi(op.Value)
+			after.Criticality = uint(c)
+		case "add-group":
+			id := ed.groupIDs[op.Value]
+			if !slices.Contains(after.Groups, id) {
+				after.Groups = append(after.Groups, id)
+			}
+		case "remove-group":
+			id := ed.groupIDs[op.Value]
+			after.Groups = slices.DeleteFunc(after.Groups, func(g string) bool { return g == id })
+		case "set-main-branch":
+			after.MainBranch = op.Value
+		}
+	}
+
+	return after
+}
+
+// Diff describes the changes between two states of the same entity, one line per change
+func (ed *Editor) Diff(before, after Entity) []string {
+	changes := []string{}
+
+	for _, key := range slices.Sorted(maps.Keys(before.Tags)) {
+		if value, ok := after.Tags[key]; !ok {
+			changes = append(changes, fmt.Sprintf("- tag %v = %v", key, before.Tags[key]))
+		} else if value != before.Tags[key] {
+			changes = append(changes, fmt.Sprintf("~ tag %v: %v -> %v", key, before.Tags[key], value))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(after.Tags)) {
+		if _, ok := before.Tags[key]; !ok {
+			changes = append(changes, fmt.Sprintf("+ tag %v = %v", key, after.Tags[key]))
+		}
+	}
+
+	if before.Criticality != after.Criticality {
+		changes = append(changes, fmt.Sprintf("~ criticality: %d -> %d", before.Criticality, after.Criticality))
+	}
+
+	for _, id := range before.Groups {
+		if !slices.Contains(after.Groups, id) {
+			changes = append(changes, fmt.Sprintf("- group %v", ed.groupName(id)))
+		}
+	}
+	for _, id := range after.Groups {
+		if !slices.Contains(before.Groups, id) {
+			changes = append(changes, fmt.Sprintf("+ group %v", ed.groupName(id)))
+		}
+	}
+
+	if before.MainBranch != after.MainBranch {
+		changes = append(changes, fmt.Sprintf("~ main branch: '%v' -> '%v'", before.MainBranch, after.MainBranch))
+	}
+
+	return changes
+}
+
+// Save writes the new state: tags, criticality and groups with UpdateProject/UpdateApplication, and the main branch with PatchProjectByID
+func (ed *Editor) Save(before, after Entity) error {
+	if after.app != nil {
+		app := *after.app
+		app.Tags = after.Tags
+		app.Criticality = after.Criticality
+		return ed.cx1client.UpdateApplication(&app)
+	}
+
+	if !maps.Equal(before.Tags, after.Tags) || before.Criticality != after.Criticality || !slices.Equal(before.Groups, after.Groups) {
+		project := *after.project
+		project.Tags = after.Tags
+		project.Criticality = after.Criticality
+		project.Groups = after.Groups
+		if err := ed.cx1client.UpdateProject(&project); err != nil {
+			return err
+		}
+	}
+
+	if before.MainBranch != after.MainBranch {
+		branch := after.MainBranch
+		if err := ed.cx1client.PatchProjectByID(after.ID, Cx1ClientGo.ProjectPatch{MainBranch: &branch}); err != nil {
+			return fmt.Errorf("failed to set main branch: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (ed *Editor) groupID(name string) (string, error) {
+	if id, ok := ed.groupIDs[name]; ok {
+		return id, nil
+	}
+
+	var group Cx1ClientGo.Group
+	var err error
+	if strings.HasPrefix(name, "/") {
+		group, err = ed.cx1client.GetGroupByPath(name)
+	} else {
+		group, err = ed.cx1client.GetGroupByName(name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("group %v not found: %s", name, err)
+	}
+
+	ed.groupIDs[name] = group.GroupID
+	ed.groupNames[group.GroupID] = group.Path
+	return group.GroupID, nil
+}
+
+func (ed *Editor) groupName(id string) string {
+	if name, ok := ed.groupNames[id]; ok {
+		return name
+	}
+	return id
+}
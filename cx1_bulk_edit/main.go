@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/bulk"
+	"github.com/cxpsemea/cx1_go_scripts/bulk/selection"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+var logger *logrus.Logger
+
+func main() {
+	logger = logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	var Ops opList
+	LogLevel := flag.String("log", "INFO", "Log level: TRACE, DEBUG, INFO, WARNING, ERROR, FATAL")
+	EntityType := flag.String("type", "project", "Type of entity to edit: project or application")
+	IDsFile := flag.String("ids", "ids.txt", "File containing 1 project or application ID per line - not used when selecting with filters")
+	OpsFile := flag.String("ops", "", "File containing 1 operation per line, applied in order")
+	flag.Var(&Ops, "op", "Operation to apply, can be repeated - applied after those from -ops. One of: add-tag key[=value], set-tag key=value, remove-tag key, rename-tag old=new, set-criticality 1-5, add-group /path, remove-group /path, set-main-branch branch")
+	Update := flag.Bool("update", false, "Apply the change or just inform")
+	Delay := flag.Int("delay", 1000, "Initial delay in milliseconds between entities")
+	MinDelay := flag.Int("min-delay", 200, "Minimum delay in milliseconds between entities when pacing adaptively")
+	MaxDelay := flag.Int("max-delay", 30000, "Maximum delay in milliseconds between entities when pacing adaptively")
+	TargetLatency := flag.Int("target-latency", 2000, "Slow down when API calls take longer than this on average, in milliseconds")
+	Adaptive := flag.Bool("adaptive", true, "Adapt the delay to API response times, throttling (429) and server errors (5xx) - set to false for a fixed -delay")
+	RevertLogFile := flag.String("revert-log", "", "File to record each project's tags before they are changed (default: bulk-edit-revert-<timestamp>.jsonl)")
+	RevertFile := flag.String("revert", "", "Revert mode: put back the project tags recorded in this revert file, skipping projects changed since")
+	RevertKeys := flag.String("revert-keys", "", "Revert mode: optional comma-separated list of tag keys to revert, other keys are left as they are")
+	Select := selection.AddFlags(true)
+
+	logger.Info("Starting")
+	client := &http.Client{}
+	pacer := bulk.NewPacer(client.Transport, logger)
+	client.Transport = pacer
+
+	cx1client, err := Cx1ClientGo.NewClient(client, logger)
+	if err != nil {
+		logger.Fatalf("Failed to create client: %v", err)
+	} else {
+		logger.Infof("Connected with %v", cx1client.String())
+	}
+
+	switch strings.ToUpper(*LogLevel) {
+	case "TRACE":
+		logger.Info("Setting log level to TRACE")
+		logger.SetLevel(logrus.TraceLevel)
+	case "DEBUG":
+		logger.Info("Setting log level to DEBUG")
+		logger.SetLevel(logrus.DebugLevel)
+	case "INFO":
+		logger.Info("Setting log level to INFO")
+		logger.SetLevel(logrus.InfoLevel)
+	case "WARNING":
+		logger.Info("Setting log level to WARNING")
+		logger.SetLevel(logrus.WarnLevel)
+	case "ERROR":
+		logger.Info("Setting log level to ERROR")
+		logger.SetLevel(logrus.ErrorLevel)
+	case "FATAL":
+		logger.Info("Setting log level to FATAL")
+		logger.SetLevel(logrus.FatalLevel)
+	default:
+		logger.Info("Log level set to default: INFO")
+	}
+
+	err = pacer.Configure(time.Duration(*Delay)*time.Millisecond, time.Duration(*MinDelay)*time.Millisecond, time.Duration(*MaxDelay)*time.Millisecond, time.Duration(*TargetLatency)*time.Millisecond, *Adaptive)
+	if err != nil {
+		logger.Fatalf("Invalid delay settings: %s", err)
+	}
+
+	if *RevertFile != "" {
+		keys := []string{}
+		for _, key := range strings.Split(*RevertKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		bulk.RevertTags(projectTagger{cx1client}, *RevertFile, keys, *Update, logger)
+		return
+	}
+
+	*EntityType = strings.ToLower(*EntityType)
+	if *EntityType != "project" && *EntityType != "application" {
+		logger.Fatalf("Invalid type %v, should be 'project' or 'application'", *EntityType)
+	}
+
+	if Select.List || Select.Active() {
+		logger.Infof("Selecting %vs: %v", *EntityType, Select.String())
+	}
+
+	if Select.List {
+		listSelection(cx1client, *EntityType, Select)
+		return
+	}
+
+	operations := []Operation{}
+	if *OpsFile != "" {
+		if operations, err = ReadOperations(*OpsFile); err != nil {
+			logger.Fatalf("Failed to read operations from %v: %v", *OpsFile, err)
+		}
+	}
+	operations = append(operations, Ops...)
+	if len(operations) == 0 {
+		logger.Fatalf("No operations provided - use -ops or -op")
+	}
+
+	editor := NewEditor(cx1client, *EntityType)
+	if err = editor.ValidateOperations(operations); err != nil {
+		logger.Fatalf("Invalid operations: %v", err)
+	}
+
+	logger.Infof("Operations to apply to each %v:", *EntityType)
+	for _, op := range operations {
+		logger.Infof(" - %v", op.String())
+	}
+
+	if *Update {
+		logger.Warnf("This will update each %v with the operations above", *EntityType)
+	} else {
+		logger.Info("This will not make any changes, only inform")
+	}
+
+	IDs := []string{}
+	if Select.Active() {
+		if IDs, err = selectIDs(cx1client, *EntityType, Select); err != nil {
+			logger.Fatalf("Failed to select %vs: %s", *EntityType, err)
+		}
+	} else {
+		file, err := os.Open(*IDsFile)
+		if err != nil {
+			logger.Fatalf("Failed to open file: %v", err)
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if id := strings.TrimSpace(scanner.Text()); id != "" {
+				IDs = append(IDs, id)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	var revertLog *bulk.RevertLog
+	if *Update && *EntityType == "project" {
+		if *RevertLogFile == "" {
+			*RevertLogFile = bulk.RevertFilename("bulk-edit")
+		}
+		if revertLog, err = bulk.OpenRevertLog(*RevertLogFile); err != nil {
+			logger.Fatalf("Failed to open revert file %v: %s", *RevertLogFile, err)
+		}
+		defer revertLog.Close()
+		logger.Infof("Previous project tags will be recorded in %v - use -revert %v to undo tag changes", *RevertLogFile, *RevertLogFile)
+	}
+
+	totalCount := len(IDs)
+	logger.Infof("Checking %d %vs...", totalCount, *EntityType)
+
+	updated, unchanged, failed := 0, 0, 0
+	for i, id := range IDs {
+		progress := fmt.Sprintf("[#%d/%d] ", i+1, totalCount)
+
+		before, err := editor.Load(id)
+		if err != nil {
+			logger.Errorf("%vFailed to get %v %v: %v", progress, *EntityType, id, err)
+			failed++
+			continue
+		}
+
+		after := editor.Apply(before, operations)
+		changes := editor.Diff(before, after)
+		if len(changes) == 0 {
+			logger.Infof("%v%v requires no changes", progress, before.String())
+			unchanged++
+			continue
+		}
+
+		logger.Infof("%v%v:", progress, before.String())
+		for _, c := range changes {
+			logger.Infof("    %v", c)
+		}
+
+		if !*Update {
+			updated++
+			continue
+		}
+
+		if revertLog != nil && !maps.Equal(before.Tags, after.Tags) {
+			if err = revertLog.Record(before.project.ProjectID, before.project.Name, before.Tags, after.Tags); err != nil {
+				logger.Fatalf("Failed to record previous tags of %v in %v: %s", before.String(), revertLog.Filename, err)
+			}
+		}
+
+		if err = editor.Save(before, after); err != nil {
+			logger.Errorf("%vFailed to update %v: %v", progress, before.String(), err)
+			failed++
+		} else {
+			logger.Infof("%vSuccessfully updated %v", progress, before.String())
+			updated++
+		}
+		pacer.Done()
+		pacer.Wait(1)
+	}
+
+	if *Update {
+		logger.Infof("Done - %d updated, %d unchanged, %d failed", updated, unchanged, failed)
+		logger.Infof("Pacing: %v", pacer.Report())
+	} else {
+		logger.Infof("Done - %d would be updated, %d unchanged, %d failed", updated, unchanged, failed)
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+	}
+}
+
+// selectIDs returns the IDs of the projects or applications matching the filters
+func selectIDs(cx1client *Cx1ClientGo.Cx1Client, entityType string, s *selection.Selection) ([]string, error) {
+	ids := []string{}
+	if entityType == "application" {
+		apps, err := s.SelectApplications(cx1client)
+		for _, a := range apps {
+			ids = append(ids, a.ApplicationID)
+		}
+		return ids, err
+	}
+
+	projects, err := s.SelectProjects(cx1client)
+	for _, p := range projects {
+		ids = append(ids, p.ProjectID)
+	}
+	return ids, err
+}
+
+func listSelection(cx1client *Cx1ClientGo.Cx1Client, entityType string, s *selection.Selection) {
+	count := 0
+	if entityType == "application" {
+		apps, err := s.SelectApplications(cx1client)
+		if err != nil {
+			logger.Fatalf("Failed to select applications: %s", err)
+		}
+		for _, a := range apps {
+			logger.Infof("%v (created: %v, tags: %v)", a.String(), a.CreatedAt, a.Tags)
+		}
+		count = len(apps)
+	} else {
+		projects, err := s.SelectProjects(cx1client)
+		if err != nil {
+			logger.Fatalf("Failed to select projects: %s", err)
+		}
+		for _, p := range projects {
+			logger.Infof("%v (primary branch: '%v', created: %v, tags: %v)", p.String(), p.MainBranch, p.CreatedAt, p.Tags)
+		}
+		count = len(projects)
+	}
+	logger.Infof("Selected %d %vs", count, entityType)
+}
+
+// projectTagger lets bulk.RevertTags read and write project tags
+type projectTagger struct {
+	cx1client *Cx1ClientGo.Cx1Client
+}
+
+func (t projectTagger) GetProjectTags(projectID string) (string, map[string]string, error) {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	return project.String(), project.Tags, err
+}
+
+func (t projectTagger) SetProjectTags(projectID string, tags map[string]string) error {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	if err != nil {
+		return err
+	}
+	project.Tags = tags
+	return t.cx1client.UpdateProject(&project)
+}
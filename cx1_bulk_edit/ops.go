@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Operation is one edit applied to every selected entity, parsed from lines such as "set-tag team=payments" or "add-group /AppSec/Payments"
+type Operation struct {
+	Action string
+	Key    string
+	Value  string
+}
+
+var operationUsage = map[string]string{
+	"add-tag":         "add-tag key[=value] - add the tag if the entity does not have it yet",
+	"set-tag":         "set-tag key=value - set the tag value, adding the tag if needed",
+	"remove-tag":      "remove-tag key - remove the tag",
+	"rename-tag":      "rename-tag old=new - rename the tag key, keeping the value",
+	"set-criticality": "set-criticality 1-5 - set the criticality",
+	"add-group":       "add-group /group/path - add the project to the group (projects only)",
+	"remove-group":    "remove-group /group/path - remove the project from the group (projects only)",
+	"set-main-branch": "set-main-branch branch - set the primary branch (projects only)",
+}
+
+func (o Operation) String() string {
+	switch o.Action {
+	case "add-tag", "set-tag", "rename-tag":
+		return fmt.Sprintf("%v %v=%v", o.Action, o.Key, o.Value)
+	case "remove-tag":
+		return fmt.Sprintf("%v %v", o.Action, o.Key)
+	}
+	return fmt.Sprintf("%v %v", o.Action, o.Value)
+}
+
+func ParseOperation(line string) (Operation, error) {
+	action, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	action = strings.ToLower(action)
+	arg = strings.TrimSpace(arg)
+	op := Operation{Action: action}
+
+	if _, ok := operationUsage[action]; !ok {
+		return op, fmt.Errorf("unknown operation '%v'", action)
+	}
+	if arg == "" {
+		return op, fmt.Errorf("missing argument, expected: %v", operationUsage[action])
+	}
+
+	switch action {
+	case "add-tag", "set-tag", "rename-tag":
+		key, value, hasValue := strings.Cut(arg, "=")
+		op.Key = strings.TrimSpace(key)
+		op.Value = strings.TrimSpace(value)
+		if op.Key == "" || (action != "add-tag" && !hasValue) || (action == "rename-tag" && op.Value == "") {
+			return op, fmt.Errorf("invalid argument '%v', expected: %v", arg, operationUsage[action])
+		}
+	case "remove-tag":
+		op.Key = arg
+	case "set-criticality":
+		if c, err := strconv.Atoi(arg); err != nil || c < 1 || c > 5 {
+			return op, fmt.Errorf("invalid criticality '%v', expected a number from 1 to 5", arg)
+		}
+		op.Value = arg
+	default:
+		op.Value = arg
+	}
+
+	return op, nil
+}
+
+// ReadOperations reads one operation per line, ignoring empty lines and # comments
+func ReadOperations(filename string) ([]Operation, error) {
+	ops := []Operation{}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return ops, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		op, err := ParseOperation(text)
+		if err != nil {
+			return ops, fmt.Errorf("line %d: %s", line, err)
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, scanner.Err()
+}
+
+// opList allows the -op flag to be repeated
+type opList []Operation
+
+func (l *opList) String() string {
+	ops := []string{}
+	for _, o := range *l {
+		ops = append(ops, o.String())
+	}
+	return strings.Join(ops, "; ")
+}
+
+func (l *opList) Set(value string) error {
+	op, err := ParseOperation(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, op)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
@@ -12,6 +13,8 @@ import (
 	"time"
 
 	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/bulk"
+	"github.com/cxpsemea/cx1_go_scripts/bulk/selection"
 	"github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
@@ -26,12 +29,22 @@ func main() {
 	logger.SetOutput(os.Stdout)
 
 	LogLevel := flag.String("log", "INFO", "Log level: TRACE, DEBUG, INFO, WARNING, ERROR, FATAL")
-	ProjectsFile := flag.String("projects", "projectIds.txt", "File containing 1 project ID per line")
+	ProjectsFile := flag.String("projects", "projectIds.txt", "File containing 1 project ID per line - not used when selecting with filters")
 	ProjectTag := flag.String("tag", "cx336-ui-fix", "Tag to add and remove")
 	Update := flag.Bool("update", false, "Apply the change or just inform")
 	AddOnly := flag.Bool("add", false, "Only add the tag, do not remove")
-	Delay := flag.Int("delay", 1000, "Delay in milliseconds between projects")
+	Delay := flag.Int("delay", 1000, "Initial delay in milliseconds between projects")
+	MinDelay := flag.Int("min-delay", 200, "Minimum delay in milliseconds between projects when pacing adaptively")
+	MaxDelay := flag.Int("max-delay", 30000, "Maximum delay in milliseconds between projects when pacing adaptively")
+	TargetLatency := flag.Int("target-latency", 2000, "Slow down when API calls take longer than this on average, in milliseconds")
+	Adaptive := flag.Bool("adaptive", true, "Adapt the delay to API response times, throttling (429) and server errors (5xx) - set to false for a fixed -delay")
 	RemoveOnly := flag.Bool("remove", false, "Only remove the tag, do not add")
+	JournalFile := flag.String("journal", "", "File to record the outcome for each project (default: bulk-tag-journal-<timestamp>.jsonl)")
+	ResumeFile := flag.String("resume", "", "Resume from a previous journal: skip projects that succeeded, retry those that failed, and keep appending to that journal")
+	RevertLogFile := flag.String("revert-log", "", "File to record each project's tags before they are changed (default: bulk-tag-revert-<timestamp>.jsonl)")
+	RevertFile := flag.String("revert", "", "Revert mode: put back the project tags recorded in this revert file, skipping projects changed since")
+	RevertKeys := flag.String("revert-keys", "", "Revert mode: optional comma-separated list of tag keys to revert, other keys are left as they are")
+	Select := selection.AddFlags(true)
 
 	logger.Info("Starting")
 	client := &http.Client{}
@@ -42,6 +55,8 @@ func main() {
 		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 		client.Transport = transport
 	}
+	pacer := bulk.NewPacer(client.Transport, logger)
+	client.Transport = pacer
 
 	cx1client, err := Cx1ClientGo.NewClient(client, logger)
 	if err != nil {
@@ -73,6 +88,38 @@ func main() {
 		logger.Info("Log level set to default: INFO")
 	}
 
+	err = pacer.Configure(time.Duration(*Delay)*time.Millisecond, time.Duration(*MinDelay)*time.Millisecond, time.Duration(*MaxDelay)*time.Millisecond, time.Duration(*TargetLatency)*time.Millisecond, *Adaptive)
+	if err != nil {
+		logger.Fatalf("Invalid delay settings: %s", err)
+	}
+
+	if *RevertFile != "" {
+		keys := []string{}
+		for _, key := range strings.Split(*RevertKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		bulk.RevertTags(projectTagger{cx1client}, *RevertFile, keys, *Update, logger)
+		return
+	}
+
+	if Select.List || Select.Active() {
+		logger.Infof("Selecting projects: %v", Select.String())
+	}
+
+	if Select.List {
+		selected, err := Select.SelectProjects(cx1client)
+		if err != nil {
+			logger.Fatalf("Failed to select projects: %s", err)
+		}
+		for _, s := range selected {
+			logger.Infof("%v (created: %v, tags: %v)", s.String(), s.CreatedAt, s.Tags)
+		}
+		logger.Infof("Selected %d projects", len(selected))
+		return
+	}
+
 	if *Update {
 		logger.Warnf("This will update project tags by adding and removing tag: %v", *ProjectTag)
 	} else {
@@ -80,7 +127,15 @@ func main() {
 	}
 	ProjectIDs := []string{}
 
-	{
+	if Select.Active() {
+		selected, err := Select.SelectProjects(cx1client)
+		if err != nil {
+			logger.Fatalf("Failed to select projects: %s", err)
+		}
+		for _, s := range selected {
+			ProjectIDs = append(ProjectIDs, s.ProjectID)
+		}
+	} else {
 		file, err := os.Open(*ProjectsFile)
 		if err != nil {
 			logger.Fatalf("Failed to open file: %v", err)
@@ -98,22 +153,78 @@ func main() {
 		}
 	}
 
+	if *ResumeFile != "" {
+		*JournalFile = *ResumeFile
+	} else if *JournalFile == "" {
+		*JournalFile = bulk.JournalFilename("bulk-tag")
+	}
+	journal, err := bulk.OpenJournal(*JournalFile, *ResumeFile != "", *Update)
+	if err != nil {
+		logger.Fatalf("Failed to open journal %v: %s", *JournalFile, err)
+	}
+	defer journal.Close()
+	record := func(id, name string, err error) {
+		if jerr := journal.Record(id, name, err); jerr != nil {
+			logger.Fatalf("Failed to write to journal %v: %s", journal.Filename, jerr)
+		}
+	}
+	if *ResumeFile != "" {
+		succeeded, failed := journal.Previous()
+		logger.Infof("Resuming from journal %v: %d projects succeeded and will be skipped, %d failed and will be retried", *ResumeFile, succeeded, failed)
+	} else if *Update {
+		logger.Infof("Recording the outcome for each project in %v - use -resume %v to continue an interrupted run", *JournalFile, *JournalFile)
+	}
+
+	var revertLog *bulk.RevertLog
+	if *Update {
+		if *RevertLogFile == "" {
+			*RevertLogFile = bulk.RevertFilename("bulk-tag")
+		}
+		if revertLog, err = bulk.OpenRevertLog(*RevertLogFile); err != nil {
+			logger.Fatalf("Failed to open revert file %v: %s", *RevertLogFile, err)
+		}
+		defer revertLog.Close()
+		logger.Infof("Previous project tags will be recorded in %v - use -revert %v to undo the changes", *RevertLogFile, *RevertLogFile)
+	}
+
 	totalCount := len(ProjectIDs)
 	logger.Infof("Checking %d projects...", totalCount)
 
 	for i, pid := range ProjectIDs {
+		progress := fmt.Sprintf("[#%d/%d] ", i+1, totalCount)
+		if journal.Skip(pid) {
+			logger.Debugf("%vSkipping project %v - already succeeded", progress, pid)
+			continue
+		}
+
 		project, err := cx1client.GetProjectByID(pid)
 		if err != nil {
 			logger.Errorf("Failed to get project %v: %v", pid, err)
+			record(pid, pid, fmt.Errorf("failed to get project: %s", err))
 		} else {
-			progress := fmt.Sprintf("[#%d/%d] ", i+1, totalCount)
-
 			if *Update {
+				if project.Tags == nil {
+					project.Tags = make(map[string]string)
+				}
+				before := maps.Clone(project.Tags)
+				after := maps.Clone(project.Tags)
+				if !*RemoveOnly {
+					after[*ProjectTag] = ""
+				}
+				if !*AddOnly {
+					delete(after, *ProjectTag)
+				}
+				if err = revertLog.Record(project.ProjectID, project.Name, before, after); err != nil {
+					logger.Fatalf("Failed to record previous tags of project %v in %v: %s", project.String(), revertLog.Filename, err)
+				}
+
+				var updateErr error
 				if !*RemoveOnly {
 					project.Tags[*ProjectTag] = ""
 					err = cx1client.UpdateProject(&project)
 					if err != nil {
 						logger.Errorf("%vFailed to add tag %v to project %v: %v", progress, *ProjectTag, project.String(), err)
+						updateErr = fmt.Errorf("failed to add tag: %s", err)
 					} else {
 						logger.Infof("%vSuccessfully updated project %v (added tag)", progress, project.String())
 					}
@@ -124,16 +235,43 @@ func main() {
 					err = cx1client.UpdateProject(&project)
 					if err != nil {
 						logger.Errorf("%vFailed to remove tag %v from project %v: %v", progress, *ProjectTag, project.String(), err)
+						updateErr = fmt.Errorf("failed to remove tag: %s", err)
 					} else {
 						logger.Infof("%vSuccessfully updated project %v (removed tag)", progress, project.String())
 					}
 				}
-				time.Sleep(time.Duration(*Delay) * time.Millisecond)
+				record(pid, project.Name, updateErr)
+				pacer.Done()
+				pacer.Wait(1)
 			} else {
 				logger.Infof("%vWould update project %v by adding & removing tag %v", progress, project.String(), *ProjectTag)
 			}
 		}
 	}
 
-	logger.Infof("Done")
+	if *Update {
+		logger.Infof("Done - %v", journal.Summary())
+		logger.Infof("Pacing: %v", pacer.Report())
+	} else {
+		logger.Infof("Done - %d skipped (already succeeded)", journal.Skipped)
+	}
+}
+
+// projectTagger lets bulk.RevertTags read and write project tags
+type projectTagger struct {
+	cx1client *Cx1ClientGo.Cx1Client
+}
+
+func (t projectTagger) GetProjectTags(projectID string) (string, map[string]string, error) {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	return project.String(), project.Tags, err
+}
+
+func (t projectTagger) SetProjectTags(projectID string, tags map[string]string) error {
+	project, err := t.cx1client.GetProjectByID(projectID)
+	if err != nil {
+		return err
+	}
+	project.Tags = tags
+	return t.cx1client.UpdateProject(&project)
 }
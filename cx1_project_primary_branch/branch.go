@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+var strategyUsage = map[string]string{
+	"priority":      "the first branch from -branchlist (case insensitive) that was scanned",
+	"regex":         "the scanned branches matching the first matching pattern from -branch-regex, eg: release/.*",
+	"most-scanned":  "the branch with the most scans",
+	"most-recent":   "the branch with the most recent completed scan",
+	"active":        "the branch with the most scans in the last -active-days days",
+	"first-scanned": "the branch of the project's first scan",
+}
+
+// BranchChooser picks the primary branch for a project by applying the strategies in order.
+// Each strategy narrows down the scanned branches: when one branch is left it is chosen, when several are tied the next strategy decides between them,
+// and a strategy that matches nothing is passed over.
+type BranchChooser struct {
+	Strategies []string
+	Priority   []string
+	Patterns   []*regexp.Regexp
+	patterns   []string // as provided, for the output
+	ActiveDays int
+	MaxScans   uint64
+}
+
+// BranchChoice is the chosen branch, why it was chosen, and the other scanned branches
+type BranchChoice struct {
+	Branch       string
+	Reason       string
+	Alternatives []string
+	Stale        string // why the current primary branch is stale, also set when no replacement was found
+}
+
+func NewBranchChooser(strategies, priority, patterns string, activeDays int, maxScans uint64) (BranchChooser, error) {
+	c := BranchChooser{
+		ActiveDays: activeDays,
+		MaxScans:   maxScans,
+	}
+
+	for _, s := range strings.Split(strategies, ",") {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		if _, ok := strategyUsage[s]; !ok {
+			return c, fmt.Errorf("unknown strategy '%v'", s)
+		}
+		c.Strategies = append(c.Strategies, s)
+	}
+	if len(c.Strategies) == 0 {
+		return c, fmt.Errorf("no strategy provided")
+	}
+
+	for _, p := range strings.Split(priority, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			c.Priority = append(c.Priority, p)
+		}
+	}
+
+	for _, p := range strings.Split(patterns, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			return c, fmt.Errorf("invalid branch pattern '%v': %s", p, err)
+		}
+		c.Patterns = append(c.Patterns, re)
+		c.patterns = append(c.patterns, p)
+	}
+
+	if slices.Contains(c.Strategies, "regex") && len(c.Patterns) == 0 {
+		return c, fmt.Errorf("the regex strategy requires -branch-regex")
+	}
+	if slices.Contains(c.Strategies, "active") && c.ActiveDays <= 0 {
+		return c, fmt.Errorf("the active strategy requires -active-days to be larger than 0")
+	}
+	return c, nil
+}
+
+func (c BranchChooser) Describe() []string {
+	lines := []string{}
+	for i, s := range c.Strategies {
+		line := fmt.Sprintf("%d: %v - %v", i+1, s, strategyUsage[s])
+		switch s {
+		case "priority":
+			line += fmt.Sprintf(" (%v)", strings.Join(c.Priority, ", "))
+		case "regex":
+			line += fmt.Sprintf(" (%v)", strings.Join(c.patterns, ", "))
+		case "active":
+			line = strings.Replace(line, "-active-days", fmt.Sprint(c.ActiveDays), 1)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Choose applies the strategies to the scanned branches of the project. The project's scans are only fetched if a strategy needs them.
+// If no strategy selects any branch, a skip message is returned instead of a choice.
+func (c BranchChooser) Choose(cx1client *Cx1ClientGo.Cx1Client, project *Cx1ClientGo.Project, branches []string) (BranchChoice, string, error) {
+	var scans []Cx1ClientGo.Scan
+	loadScans := func() ([]Cx1ClientGo.Scan, error) {
+		if scans != nil {
+			return scans, nil
+		}
+		_, s, err := cx1client.GetXScansFiltered(Cx1ClientGo.ScanFilter{
+			ProjectID:  project.ProjectID,
+			Sort:       []string{"-created_at"},
+			BaseFilter: Cx1ClientGo.BaseFilter{Limit: 100},
+		}, c.MaxScans)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scans for project %v: %v", project.String(), err)
+		}
+		scans = s
+		if scans == nil {
+			scans = []Cx1ClientGo.Scan{}
+		}
+		return scans, nil
+	}
+
+	candidates := slices.Clone(branches)
+	reasons := []string{}
+	for _, strategy := range c.Strategies {
+		narrowed, reason, err := c.apply(strategy, cx1client, project, candidates, loadScans)
+		if err != nil {
+			return BranchChoice{}, "", err
+		}
+		if len(narrowed) == 0 {
+			continue
+		}
+
+		candidates = narrowed
+		if len(candidates) == 1 {
+			reasons = append(reasons, reason)
+			return c.choice(candidates[0], strings.Join(reasons, ", then "), branches), "", nil
+		}
+		reasons = append(reasons, fmt.Sprintf("%v (tied: %v)", reason, strings.Join(candidates, ", ")))
+	}
+
+	if len(reasons) == 0 {
+		return BranchChoice{}, fmt.Sprintf("Skipping project %v - no strategy selected a branch among %v", project.String(), strings.Join(branches, ", ")), nil
+	}
+	reasons = append(reasons, "first of the remaining branches")
+	return c.choice(candidates[0], strings.Join(reasons, ", then "), branches), "", nil
+}
+
+func (c BranchChooser) choice(branch, reason string, branches []string) BranchChoice {
+	alternatives := []string{}
+	for _, b := range branches {
+		if b != branch {
+			alternatives = append(alternatives, b)
+		}
+	}
+	return BranchChoice{Branch: branch, Reason: reason, Alternatives: alternatives}
+}
+
+// apply returns the candidates selected by one strategy, or none if it does not apply to this project
+func (c BranchChooser) apply(strategy string, cx1client *Cx1ClientGo.Cx1Client, project *Cx1ClientGo.Project, candidates []string, loadScans func() ([]Cx1ClientGo.Scan, error)) ([]string, string, error) {
+	switch strategy {
+	case "priority":
+		for i, p := range c.Priority {
+			for _, b := range candidates {
+				if strings.EqualFold(p, b) {
+					return []string{b}, fmt.Sprintf("priority: '%v' is #%d in the priority list", p, i+1), nil
+				}
+			}
+		}
+
+	case "regex":
+		for i, re := range c.Patterns {
+			matches := slices.DeleteFunc(slices.Clone(candidates), func(b string) bool { return !re.MatchString(b) })
+			if len(matches) > 0 {
+				return matches, fmt.Sprintf("regex: matches pattern #%d '%v'", i+1, c.patterns[i]), nil
+			}
+		}
+
+	case "most-scanned", "active":
+		scans, err := loadScans()
+		if err != nil {
+			return nil, "", err
+		}
+		since := time.Now().AddDate(0, 0, -c.ActiveDays)
+		counts := make(map[string]int)
+		for _, s := range scans {
+			if strategy == "active" {
+				if created, err := time.Parse(time.RFC3339Nano, s.CreatedAt); err != nil || created.Before(since) {
+					continue
+				}
+			}
+			if slices.Contains(candidates, s.Branch) {
+				counts[s.Branch]++
+			}
+		}
+
+		top := 0
+		for _, count := range counts {
+			top = max(top, count)
+		}
+		if top == 0 {
+			break
+		}
+		winners := []string{}
+		for _, b := range candidates {
+			if counts[b] == top {
+				winners = append(winners, b)
+			}
+		}
+		if strategy == "active" {
+			return winners, fmt.Sprintf("active: %d scans in the last %d days", top, c.ActiveDays), nil
+		}
+		return winners, fmt.Sprintf("most-scanned: %d of the last %d scans", top, len(scans)), nil
+
+	case "most-recent":
+		scans, err := loadScans()
+		if err != nil {
+			return nil, "", err
+		}
+		for _, s := range scans {
+			if s.Status == "Completed" && slices.Contains(candidates, s.Branch) {
+				return []string{s.Branch}, fmt.Sprintf("most-recent: completed scan on %v", s.CreatedAt), nil
+			}
+		}
+
+	case "first-scanned":
+		_, scan, err := cx1client.GetScansFiltered(Cx1ClientGo.ScanFilter{
+			ProjectID:  project.ProjectID,
+			Sort:       []string{"+created_at"},
+			BaseFilter: Cx1ClientGo.BaseFilter{Limit: 1},
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get first scan for project %v: %v", project.String(), err)
+		}
+		if len(scan) > 0 && slices.Contains(candidates, scan[0].Branch) {
+			return []string{scan[0].Branch}, fmt.Sprintf("first-scanned: first scan on %v", scan[0].CreatedAt), nil
+		}
+	}
+
+	return nil, "", nil
+}
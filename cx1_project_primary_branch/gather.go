@@ -0,0 +1,81 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// GatherResult is the branch choice for one project, computed by a worker
+type GatherResult struct {
+	Choice  BranchChoice
+	SkipMsg string
+	Err     error
+}
+
+const progressInterval = 30 * time.Second
+
+// gatherBranches runs getPrimaryBranch for every project on a bounded pool of workers. Each worker waits at least workerDelay between
+// the start of two projects, so the load on the server is limited to workers / workerDelay projects per second.
+// The results are returned in the same order as the projects, so that they can be processed serially afterwards.
+func gatherBranches(cx1client *Cx1ClientGo.Cx1Client, projects []*Cx1ClientGo.Project, workers int, workerDelay time.Duration) []GatherResult {
+	results := make([]GatherResult, len(projects))
+	if len(projects) == 0 {
+		return results
+	}
+	workers = max(1, min(workers, len(projects)))
+
+	jobs := make(chan int)
+	var done atomic.Int64
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				start := time.Now()
+				choice, skipmsg, err := getPrimaryBranch(cx1client, projects[i])
+				results[i] = GatherResult{Choice: choice, SkipMsg: skipmsg, Err: err}
+				done.Add(1)
+
+				if wait := workerDelay - time.Since(start); wait > 0 {
+					time.Sleep(wait)
+				}
+			}
+		}()
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		started := time.Now()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				count := done.Load()
+				elapsed := time.Since(started)
+				rate := float64(count) / elapsed.Minutes()
+				eta := "unknown"
+				if count > 0 {
+					eta = (time.Duration(float64(elapsed) / float64(count) * float64(int64(len(projects))-count))).Round(time.Second).String()
+				}
+				logger.Infof("Gathered branches for %d of %d projects (%.1f per minute, %d workers) - estimated time remaining: %v", count, len(projects), rate, workers, eta)
+			}
+		}
+	}()
+
+	for i := range projects {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	close(stop)
+
+	return results
+}
@@ -10,11 +10,12 @@ import (
 	"time"
 
 	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/bulk"
 	"github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
 
-var PriorityBranches []string
+var Chooser BranchChooser
 var logger *logrus.Logger
 
 func main() {
@@ -29,10 +30,20 @@ func main() {
 	LogLevel := flag.String("log", "INFO", "Log level: TRACE, DEBUG, INFO, WARNING, ERROR, FATAL")
 	ProjectsFile := flag.String("projects", "", "Optional: file containing 1 project ID per line (otherwise check all projects)")
 	ApplicationName := flag.String("appName", "", "Optional: name of application containing projects to update")
-	BranchesFile := flag.String("branches", "", "Optional: file containing one <projectId, branchName> per line - best performance")
-	BranchList := flag.String("branchlist", "main,master", "Optional: comma-separated list of branch names (case insensitive) to set as primary if it was scanned, by order of priority - set to first-scanned branch otherwise")
+	BranchesFile := flag.String("branches", "", "Optional: file containing one <projectId, branchName> per line, or a plan file written with -plan - best performance")
+	PlanFile := flag.String("plan", "", "Optional: write the chosen branches as projectId,projectName,branch,reason,alternatives to this CSV file for review, without updating - apply the reviewed file later with -branches")
+	BranchList := flag.String("branchlist", "main,master", "Optional: comma-separated list of branch names (case insensitive) to set as primary if it was scanned, by order of priority - used by the 'priority' strategy")
+	Strategies := flag.String("strategy", "priority,first-scanned", "Comma-separated chain of strategies to choose the primary branch, applied in order until one branch is left: priority, regex, most-scanned, most-recent, active, first-scanned")
+	BranchPatterns := flag.String("branch-regex", "", "Optional: comma-separated list of regular expressions matching the whole branch name, by order of priority - used by the 'regex' strategy, eg: release/.*,develop")
+	ActiveDays := flag.Int("active-days", 90, "Number of days used by the 'active' strategy")
+	MaxScans := flag.Int("max-scans", 500, "Maximum number of recent scans per project used by the 'most-scanned', 'most-recent' and 'active' strategies")
+	flag.IntVar(&StaleDays, "stale-days", 0, "Optional: also check projects that have a primary branch, and propose a replacement if that branch has no completed scan in this many days")
 	Update := flag.Bool("update", false, "Apply the change or just inform")
-	Delay := flag.Int("delay", 1000, "Delay in milliseconds between projects")
+	Delay := flag.Int("delay", 1000, "Delay in milliseconds between projects when updating")
+	Workers := flag.Int("workers", 1, "Number of concurrent workers gathering the branches when checking all projects - updates are always made one at a time")
+	WorkerDelay := flag.Int("worker-delay", 0, "Minimum time in milliseconds between two projects for each worker while gathering, eg: 200 to limit the load with many workers")
+	JournalFile := flag.String("journal", "", "File to record the outcome for each project (default: primary-branch-journal-<timestamp>.jsonl)")
+	ResumeFile := flag.String("resume", "", "Resume from a previous journal: skip projects that succeeded, retry those that failed, and keep appending to that journal")
 
 	logger.Info("Starting")
 	client := &http.Client{}
@@ -53,12 +64,13 @@ func main() {
 		logger.Infof("Connected with %v", cx1client.String())
 	}
 
-	PriorityBranches = strings.Split(*BranchList, ",")
-	if len(PriorityBranches) > 0 {
-		logger.Infof("Will set primary branches per project according to first-match in the priority list:")
-		for i, p := range PriorityBranches {
-			logger.Infof("%d: %v", i+1, p)
-		}
+	Chooser, err = NewBranchChooser(*Strategies, *BranchList, *BranchPatterns, *ActiveDays, uint64(*MaxScans))
+	if err != nil {
+		logger.Fatalf("Invalid branch selection: %s", err)
+	}
+	logger.Infof("Will set primary branches per project according to the first strategy that leaves a single branch:")
+	for _, line := range Chooser.Describe() {
+		logger.Infof("%v", line)
 	}
 
 	switch strings.ToUpper(*LogLevel) {
@@ -84,15 +96,47 @@ func main() {
 		logger.Info("Log level set to default: INFO")
 	}
 
+	if *PlanFile != "" && *Update {
+		logger.Warnf("The 'update' flag is ignored when writing a plan - apply the reviewed plan with -branches")
+		*Update = false
+	}
+
+	if StaleDays > 0 {
+		logger.Infof("Projects whose primary branch has no completed scan in the last %d days will get a replacement chosen among the branches scanned in that time", StaleDays)
+	}
+
 	if *Update {
-		logger.Warnf("This will update projects by setting the primary branch for projects without a primary branch and only one scanned branch")
+		if StaleDays > 0 {
+			logger.Warnf("This will update projects by setting the primary branch for projects without a primary branch, and by replacing stale primary branches")
+		} else {
+			logger.Warnf("This will update projects by setting the primary branch for projects without a primary branch")
+		}
 	} else {
 		logger.Info("This will not make any changes, only inform")
 	}
 
+	if *ResumeFile != "" {
+		*JournalFile = *ResumeFile
+	} else if *JournalFile == "" {
+		*JournalFile = bulk.JournalFilename("primary-branch")
+	}
+	journal, err := bulk.OpenJournal(*JournalFile, *ResumeFile != "", *Update)
+	if err != nil {
+		logger.Fatalf("Failed to open journal %v: %s", *JournalFile, err)
+	}
+	defer journal.Close()
+	if *ResumeFile != "" {
+		succeeded, failed := journal.Previous()
+		logger.Infof("Resuming from journal %v: %d projects succeeded and will be skipped, %d failed and will be retried", *ResumeFile, succeeded, failed)
+	} else if *Update {
+		logger.Infof("Recording the outcome for each project in %v - use -resume %v to continue an interrupted run", *JournalFile, *JournalFile)
+	}
+
 	//Projects := []Cx1ClientGo.Project{}
 	ProjectBranches := make(map[string]string)
 	ProjectMap := make(map[string]string)
+	ProjectChoices := make(map[string]BranchChoice)
+	ProjectNames := make(map[string]string)
 
 	if *ProjectsFile != "" {
 		logger.Infof("Parsing list of project IDs from %v", *ProjectsFile)
@@ -107,18 +151,27 @@ func main() {
 		for scanner.Scan() {
 			//logger.Infof("Read line: %v", scanner.Text())
 			ProjectID := scanner.Text()
+			if journal.Skip(ProjectID) {
+				pcount++
+				continue
+			}
 			project, err := cx1client.GetProjectByID(ProjectID)
 			if err != nil {
 				logger.Errorf("%d: Failed to get project %v: %v", pcount+1, ProjectID, err)
+				recordOutcome(journal, ProjectID, ProjectID, err)
 			} else {
 				ProjectMap[ProjectID] = project.String()
-				branch, skipmsg, err := getPrimaryBranch(cx1client, &project)
+				ProjectNames[ProjectID] = project.Name
+				choice, skipmsg, err := getPrimaryBranch(cx1client, &project)
 				if err != nil {
 					logger.Errorf("%d: %v", pcount+1, err)
+					recordOutcome(journal, ProjectID, project.Name, err)
 				} else if skipmsg != "" {
 					logger.Warningf("%d: %v", pcount+1, skipmsg)
 				} else {
-					ProjectBranches[ProjectID] = branch
+					ProjectBranches[ProjectID] = choice.Branch
+					ProjectChoices[ProjectID] = choice
+					logger.Infof("%d: Project %v: '%v' - %v", pcount+1, project.String(), choice.Branch, choice.Reason)
 				}
 			}
 			pcount++
@@ -140,44 +193,56 @@ func main() {
 		logger.Infof("Application %v has %d projects", application.ApplicationID, len(*application.ProjectIds))
 
 		for pcount, ProjectID := range *application.ProjectIds {
+			if journal.Skip(ProjectID) {
+				continue
+			}
 			project, err := cx1client.GetProjectByID(ProjectID)
 			if err != nil {
 				logger.Errorf("%d: Failed to get project %v: %v", pcount+1, ProjectID, err)
+				recordOutcome(journal, ProjectID, ProjectID, err)
 			} else {
 				ProjectMap[ProjectID] = project.String()
-				branch, skipmsg, err := getPrimaryBranch(cx1client, &project)
+				ProjectNames[ProjectID] = project.Name
+				choice, skipmsg, err := getPrimaryBranch(cx1client, &project)
 				if err != nil {
 					logger.Errorf("%d: %v", pcount+1, err)
+					recordOutcome(journal, ProjectID, project.Name, err)
+				} else if skipmsg != "" && choice.Stale != "" {
+					logger.Warnf("%d: %v", pcount+1, skipmsg)
 				} else if skipmsg != "" {
 					logger.Debugf("%d: %v", pcount+1, skipmsg)
 				} else {
-					ProjectBranches[ProjectID] = branch
+					ProjectBranches[ProjectID] = choice.Branch
+					ProjectChoices[ProjectID] = choice
+					logger.Infof("%d: Project %v: '%v' - %v", pcount+1, project.String(), choice.Branch, choice.Reason)
 				}
 			}
 		}
 	} else if *BranchesFile != "" {
+		if *PlanFile != "" {
+			logger.Fatalf("The 'plan' flag cannot be combined with -branches")
+		}
 		logger.Infof("Parsing list of project IDs and branches from %v", *BranchesFile)
-		file, err := os.Open(*BranchesFile)
+		rows, err := readBranches(*BranchesFile)
 		if err != nil {
-			logger.Fatalf("Failed to open file: %v", err)
+			logger.Fatalf("Failed to read %v: %v", *BranchesFile, err)
 		}
-		defer file.Close()
 
-		scanner := bufio.NewScanner(file)
-		pcount := 0
-		for scanner.Scan() {
-			//logger.Infof("Read line: %v", scanner.Text())
-			parts := strings.Split(scanner.Text(), ",")
-			if len(parts) != 2 {
-				logger.Errorf("%d: Failed to parse line '%v', should be in <projectId,branch> format", pcount+1, scanner.Text())
+		for pcount, row := range rows {
+			if row.Branch == "" {
+				logger.Infof("%d: Skipping project %v - no branch in %v", pcount+1, row.ProjectID, *BranchesFile)
 				continue
 			}
-			ProjectBranches[parts[0]] = parts[1]
-			pcount++
-		}
-
-		if err := scanner.Err(); err != nil {
-			logger.Fatal(err)
+			if journal.Skip(row.ProjectID) {
+				continue
+			}
+			ProjectBranches[row.ProjectID] = row.Branch
+			if row.ProjectName != "" {
+				ProjectMap[row.ProjectID] = row.ProjectName
+			}
+			if row.Reason != "" {
+				ProjectChoices[row.ProjectID] = BranchChoice{Branch: row.Branch, Reason: row.Reason}
+			}
 		}
 	} else {
 		logger.Infof("Fetching all projects")
@@ -196,25 +261,63 @@ func main() {
 		}
 
 		logger.Infof("Got %d projects", len(Projects))
-		for pcount, project := range Projects {
+		pending := []*Cx1ClientGo.Project{}
+		for pcount := range Projects {
+			project := &Projects[pcount]
+			if journal.Skip(project.ProjectID) {
+				continue
+			}
 			ProjectMap[project.ProjectID] = project.String()
-			var branch, skipmsg string
+			ProjectNames[project.ProjectID] = project.Name
 
 			if overview, ok := overviewMap[project.ProjectID]; ok && overview.LastScanDate == "" {
-				skipmsg = fmt.Sprintf("Skipping project %v - has no scans", project.String())
+				logger.Debugf("%d: Skipping project %v - has no scans", pcount+1, project.String())
+			} else if project.MainBranch != "" && StaleDays <= 0 {
+				logger.Debugf("%d: Skipping project %v - already has primary branch '%v'", pcount+1, project.String(), project.MainBranch)
 			} else {
-				branch, skipmsg, err = getPrimaryBranch(cx1client, &project)
+				pending = append(pending, project)
 			}
-			if err != nil {
-				logger.Errorf("%d: %v", pcount+1, err)
-			} else if skipmsg != "" {
-				logger.Debugf("%d: %v", pcount+1, skipmsg)
+		}
+
+		logger.Infof("Gathering branches for %d projects with %d workers", len(pending), *Workers)
+		results := gatherBranches(cx1client, pending, *Workers, time.Duration(*WorkerDelay)*time.Millisecond)
+
+		for pcount, result := range results {
+			project := pending[pcount]
+			if result.Err != nil {
+				logger.Errorf("%d: %v", pcount+1, result.Err)
+				recordOutcome(journal, project.ProjectID, project.Name, result.Err)
+			} else if result.SkipMsg != "" && result.Choice.Stale != "" {
+				logger.Warnf("%d: %v", pcount+1, result.SkipMsg)
+			} else if result.SkipMsg != "" {
+				logger.Debugf("%d: %v", pcount+1, result.SkipMsg)
 			} else {
-				ProjectBranches[project.ProjectID] = branch
+				ProjectBranches[project.ProjectID] = result.Choice.Branch
+				ProjectChoices[project.ProjectID] = result.Choice
+				logger.Infof("%d: Project %v: '%v' - %v", pcount+1, project.String(), result.Choice.Branch, result.Choice.Reason)
 			}
 		}
 	}
 
+	if *PlanFile != "" {
+		rows := []PlanRow{}
+		for projectId, branch := range ProjectBranches {
+			choice := ProjectChoices[projectId]
+			rows = append(rows, PlanRow{
+				ProjectID:    projectId,
+				ProjectName:  ProjectNames[projectId],
+				Branch:       branch,
+				Reason:       choice.Reason,
+				Alternatives: choice.Alternatives,
+			})
+		}
+		if err = writePlan(*PlanFile, rows); err != nil {
+			logger.Fatalf("Failed to write plan %v: %s", *PlanFile, err)
+		}
+		logger.Infof("Wrote the primary branch plan for %d projects to %v - review it, then apply it with -branches %v -update", len(rows), *PlanFile, *PlanFile)
+		return
+	}
+
 	totalCount := len(ProjectBranches)
 	logger.Infof("Processing %d projects...", totalCount)
 
@@ -232,57 +335,48 @@ func main() {
 			} else {
 				logger.Infof("%vSuccessfully set primary branch '%v' on project %v", progress, branch, p_string)
 			}
+			recordOutcome(journal, projectId, p_string, err)
 
 			if i+1 < totalCount {
 				time.Sleep(time.Duration(*Delay) * time.Millisecond)
 			}
 		} else {
-			logger.Infof("%vWould update project %v by setting the primary branch to '%v'", progress, p_string, branch)
+			if choice, ok := ProjectChoices[projectId]; ok {
+				logger.Infof("%vWould update project %v by setting the primary branch to '%v' (%v)", progress, p_string, branch, choice.Reason)
+			} else {
+				logger.Infof("%vWould update project %v by setting the primary branch to '%v'", progress, p_string, branch)
+			}
 		}
 		i++
 	}
 
-	logger.Infof("Done")
+	if *Update {
+		logger.Infof("Done - %v", journal.Summary())
+	} else {
+		logger.Infof("Done - %d skipped (already succeeded)", journal.Skipped)
+	}
+}
+
+func recordOutcome(journal *bulk.Journal, id, name string, err error) {
+	if jerr := journal.Record(id, name, err); jerr != nil {
+		logger.Fatalf("Failed to write to journal %v: %s", journal.Filename, jerr)
+	}
 }
 
-func getPrimaryBranch(cx1client *Cx1ClientGo.Cx1Client, project *Cx1ClientGo.Project) (string, string, error) {
+func getPrimaryBranch(cx1client *Cx1ClientGo.Cx1Client, project *Cx1ClientGo.Project) (BranchChoice, string, error) {
 	if project.MainBranch != "" {
-		return "", fmt.Sprintf("Skipping project %v - already has primary branch '%v'", project.String(), project.MainBranch), nil
+		if StaleDays > 0 {
+			return getReplacementBranch(cx1client, project)
+		}
+		return BranchChoice{}, fmt.Sprintf("Skipping project %v - already has primary branch '%v'", project.String(), project.MainBranch), nil
 	}
 
 	branches, err := cx1client.GetProjectBranchesByID(project.ProjectID)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get branches for project %v: %v", project.String(), err)
+		return BranchChoice{}, "", fmt.Errorf("failed to get branches for project %v: %v", project.String(), err)
 	} else if len(branches) == 0 {
-		return "", fmt.Sprintf("project %v has no scanned branches", project.String()), nil
-	}
-
-	// check priority list of branches
-	primaryBranch := ""
-	for _, p := range PriorityBranches {
-		for _, b := range branches {
-			if strings.EqualFold(p, b) {
-				return b, "", nil
-			}
-		}
-	}
-	// else check first scan branch
-	if primaryBranch == "" {
-		_, scan, err := cx1client.GetScansFiltered(Cx1ClientGo.ScanFilter{
-			ProjectID:  project.ProjectID,
-			Sort:       []string{"+created_at"},
-			BaseFilter: Cx1ClientGo.BaseFilter{Limit: 1},
-		})
-
-		if err != nil {
-			return "", "", fmt.Errorf("failed to get first scan for project %v: %v", project.String(), err)
-		}
-		if len(scan) == 0 {
-			return "", "", fmt.Errorf("failed to get first scan for project %v", project.String())
-		}
-		return scan[0].Branch, "", nil
-
+		return BranchChoice{}, fmt.Sprintf("project %v has no scanned branches", project.String()), nil
 	}
 
-	return branches[0], "", nil
+	return Chooser.Choose(cx1client, project, branches)
 }
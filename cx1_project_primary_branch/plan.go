@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// PlanRow is one line of the branch plan: projectId,projectName,branch,reason,alternatives
+type PlanRow struct {
+	ProjectID    string
+	ProjectName  string
+	Branch       string
+	Reason       string
+	Alternatives []string
+}
+
+var planHeader = []string{"projectId", "projectName", "branch", "reason", "alternatives"}
+
+// writePlan writes the rows sorted by project name, with the alternative branches separated by semicolons
+func writePlan(filename string, rows []PlanRow) error {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].ProjectName != rows[j].ProjectName {
+			return rows[i].ProjectName < rows[j].ProjectName
+		}
+		return rows[i].ProjectID < rows[j].ProjectID
+	})
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err = w.Write(planHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err = w.Write([]string{r.ProjectID, r.ProjectName, r.Branch, r.Reason, strings.Join(r.Alternatives, ";")}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// readBranches reads either a plain "projectId,branch" file or a plan file - the header line and any columns after the branch are ignored.
+// Rows with an empty branch are returned too, so that projects removed from an edited plan can be reported.
+func readBranches(filename string) ([]PlanRow, error) {
+	rows := []PlanRow{}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return rows, err
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	line := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return rows, fmt.Errorf("line %d: %s", line, err)
+		}
+
+		if line == 1 && strings.EqualFold(record[0], planHeader[0]) {
+			continue
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		switch len(record) {
+		case 0, 1:
+			return rows, fmt.Errorf("line %d: '%v' should be in <projectId,branch> format", line, strings.Join(record, ","))
+		case 2:
+			rows = append(rows, PlanRow{ProjectID: strings.TrimSpace(record[0]), Branch: record[1]})
+		default:
+			row := PlanRow{ProjectID: strings.TrimSpace(record[0]), ProjectName: record[1], Branch: record[2]}
+			if len(record) > 3 {
+				row.Reason = record[3]
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
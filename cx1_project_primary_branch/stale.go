@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// StaleDays enables the stale mode: projects whose primary branch has no completed scan in this many days get a replacement proposed
+var StaleDays int
+
+// getReplacementBranch checks whether the project's primary branch is stale, and if so chooses a replacement among the branches
+// that do have a completed scan in the last StaleDays days, using the same strategies as for projects without a primary branch
+func getReplacementBranch(cx1client *Cx1ClientGo.Cx1Client, project *Cx1ClientGo.Project) (BranchChoice, string, error) {
+	since := time.Now().AddDate(0, 0, -StaleDays)
+
+	_, scans, err := cx1client.GetAllScansFiltered(Cx1ClientGo.ScanFilter{
+		ProjectID:  project.ProjectID,
+		Statuses:   []string{"Completed"},
+		FromDate:   since,
+		Sort:       []string{"-created_at"},
+		BaseFilter: Cx1ClientGo.BaseFilter{Limit: 100},
+	})
+	if err != nil {
+		return BranchChoice{}, "", fmt.Errorf("failed to get recent scans for project %v: %v", project.String(), err)
+	}
+
+	active := []string{}
+	for _, s := range scans {
+		if s.Branch == project.MainBranch {
+			return BranchChoice{}, fmt.Sprintf("Skipping project %v - primary branch '%v' has a completed scan on %v", project.String(), project.MainBranch, s.CreatedAt), nil
+		}
+		if !slices.Contains(active, s.Branch) {
+			active = append(active, s.Branch)
+		}
+	}
+
+	stale := fmt.Sprintf("no completed scan in the last %d days", StaleDays)
+	_, last, err := cx1client.GetScansFiltered(Cx1ClientGo.ScanFilter{
+		ProjectID:  project.ProjectID,
+		Branches:   []string{project.MainBranch},
+		Statuses:   []string{"Completed"},
+		Sort:       []string{"-created_at"},
+		BaseFilter: Cx1ClientGo.BaseFilter{Limit: 1},
+	})
+	if err != nil {
+		return BranchChoice{}, "", fmt.Errorf("failed to get last scan of branch '%v' for project %v: %v", project.MainBranch, project.String(), err)
+	}
+	if len(last) == 0 {
+		stale = "never scanned successfully"
+	} else {
+		stale += fmt.Sprintf(", last on %v", last[0].CreatedAt)
+	}
+
+	if len(active) == 0 {
+		return BranchChoice{Stale: stale}, fmt.Sprintf("Skipping project %v - primary branch '%v' is stale (%v), but no other branch was scanned in that time", project.String(), project.MainBranch, stale), nil
+	}
+
+	choice, skipmsg, err := Chooser.Choose(cx1client, project, active)
+	if err != nil {
+		return choice, "", err
+	}
+	choice.Stale = stale
+	if skipmsg != "" {
+		return choice, fmt.Sprintf("%v - primary branch '%v' is stale (%v)", skipmsg, project.MainBranch, stale), nil
+	}
+	choice.Reason = fmt.Sprintf("replaces stale '%v' (%v), %v", project.MainBranch, stale, choice.Reason)
+	return choice, "", nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+)
+
+// AuditEntry is written as one JSON line per change (or attempted change) to a user
+type AuditEntry struct {
+	Time     string `json:"time"`
+	UserID   string `json:"userId"`
+	UserName string `json:"username"`
+	Email    string `json:"email"`
+	Action   string `json:"action"`
+	Detail   string `json:"detail,omitempty"`
+	Result   string `json:"result"`
+}
+
+type AuditLog struct {
+	file *os.File
+}
+
+func NewAuditLog(filename string) (*AuditLog, error) {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{file: file}, nil
+}
+
+func (a *AuditLog) Record(user *Cx1ClientGo.User, action, detail string, err error) error {
+	entry := AuditEntry{
+		Time:     time.Now().UTC().Format(time.RFC3339),
+		UserID:   user.UserID,
+		UserName: user.UserName,
+		Email:    user.Email,
+		Action:   action,
+		Detail:   detail,
+		Result:   "ok",
+	}
+	if err != nil {
+		entry.Result = err.Error()
+	}
+
+	data, _ := json.Marshal(entry)
+	_, werr := a.file.Write(append(data, '\n'))
+	return werr
+}
+
+func (a *AuditLog) Close() error {
+	return a.file.Close()
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+var logger *logrus.Logger
+
+func main() {
+	logger = logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	logger.Info("Starting")
+	logger.Info("The purpose of this tool is to find CheckmarxOne users who are no longer in the active employee export, and optionally disable or delete them.")
+
+	ActiveFile := flag.String("active", "active.csv", "CSV export of active employees with a header row containing an 'email' and/or 'username' column")
+	ExcludeFile := flag.String("exclude", "", "Optional: file containing 1 username or email per line for accounts that must never be deprovisioned (service & break-glass accounts)")
+	Action := flag.String("action", "disable", "Action for users who are no longer active: 'disable' or 'delete'")
+	AuditFile := flag.String("audit", "deprovisioning-audit.jsonl", "File to which every change is appended as a JSON line")
+	Update := flag.Bool("update", false, "Apply the changes, otherwise only list the users that are no longer active")
+
+	httpClient := &http.Client{}
+	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
+	if err != nil {
+		logger.Fatalf("Error creating client: %s", err)
+	}
+	logger.Infof("Connected with %v", cx1client.String())
+
+	*Action = strings.ToLower(*Action)
+	if *Action != "disable" && *Action != "delete" {
+		logger.Fatalf("Invalid action %v, should be 'disable' or 'delete'", *Action)
+	}
+
+	active, err := readActiveFile(*ActiveFile)
+	if err != nil {
+		logger.Fatalf("Failed to read active employees file %v: %s", *ActiveFile, err)
+	}
+	logger.Infof("Read %d active usernames & emails from %v", len(active), *ActiveFile)
+	if len(active) == 0 {
+		logger.Fatalf("No active employees were read from %v - refusing to continue", *ActiveFile)
+	}
+
+	excluded := make(map[string]bool)
+	if *ExcludeFile != "" {
+		if excluded, err = readExcludeFile(*ExcludeFile); err != nil {
+			logger.Fatalf("Failed to read exclusion file %v: %s", *ExcludeFile, err)
+		}
+		logger.Infof("Read %d excluded usernames & emails from %v", len(excluded), *ExcludeFile)
+	}
+
+	if *Update {
+		logger.Warnf("The 'update' flag is set - users who are no longer active will be removed from their groups and %vd", *Action)
+	} else {
+		logger.Warn("The 'update' flag is not set - no changes will be made, but only printed to the console")
+	}
+
+	users, err := cx1client.GetAllUsers()
+	if err != nil {
+		logger.Fatalf("Failed to get users: %s", err)
+	}
+	logger.Infof("Checking %d users", len(users))
+
+	var audit *AuditLog
+	if *Update {
+		if audit, err = NewAuditLog(*AuditFile); err != nil {
+			logger.Fatalf("Failed to open audit file %v: %s", *AuditFile, err)
+		}
+		defer audit.Close()
+	}
+
+	inactive, changed, failed := 0, 0, 0
+	for _, user := range users {
+		if active[strings.ToLower(user.Email)] || active[strings.ToLower(user.UserName)] {
+			continue
+		}
+
+		if excluded[strings.ToLower(user.Email)] || excluded[strings.ToLower(user.UserName)] {
+			logger.Infof("User %v is not active but is in the exclusion list - skipping", user.String())
+			continue
+		}
+
+		if strings.HasPrefix(user.UserName, "service-account-") {
+			logger.Debugf("User %v is an OIDC client service account - skipping", user.String())
+			continue
+		}
+
+		if owner, err := cx1client.UserIsTenantOwner(&user); err == nil && owner {
+			logger.Warnf("User %v is not active but is the tenant owner - skipping", user.String())
+			continue
+		}
+
+		inactive++
+		groups, err := cx1client.GetUserGroups(&user)
+		if err != nil {
+			logger.Errorf("Failed to get groups for user %v: %s", user.String(), err)
+			failed++
+			continue
+		}
+
+		if *Action == "disable" && !user.Enabled && len(groups) == 0 {
+			logger.Infof("User %v is not active and was already disabled with no groups", user.String())
+			continue
+		}
+
+		if !*Update {
+			logger.Infof("User %v is not active: would remove from %d groups and %v", user.String(), len(groups), *Action)
+			continue
+		}
+
+		if err = deprovisionUser(cx1client, &user, groups, *Action, audit); err != nil {
+			logger.Errorf("Failed to deprovision user %v: %s", user.String(), err)
+			failed++
+		} else {
+			changed++
+		}
+	}
+
+	logger.Infof("Found %d users who are no longer active", inactive)
+	if *Update {
+		logger.Infof("Deprovisioned %d users, %d failed - changes were recorded in %v", changed, failed, *AuditFile)
+	} else {
+		logger.Warnf("No changes were applied. To apply changes, re-run with the -update flag set.")
+	}
+}
+
+func deprovisionUser(cx1client *Cx1ClientGo.Cx1Client, user *Cx1ClientGo.User, groups []Cx1ClientGo.Group, action string, audit *AuditLog) error {
+	for _, g := range groups {
+		err := cx1client.RemoveUserFromGroupByID(user, g.GroupID)
+		if aerr := audit.Record(user, "remove-group", g.Path, err); aerr != nil {
+			return fmt.Errorf("failed to write audit log: %s", aerr)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove from group %v: %s", g.Path, err)
+		}
+		logger.Infof("Removed user %v from group %v", user.String(), g.Path)
+	}
+
+	var err error
+	if action == "delete" {
+		err = cx1client.DeleteUser(user)
+	} else if user.Enabled {
+		user.Enabled = false
+		err = cx1client.UpdateUser(user)
+	} else {
+		return nil
+	}
+
+	if aerr := audit.Record(user, action, "", err); aerr != nil {
+		return fmt.Errorf("failed to write audit log: %s", aerr)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("User %v: %vd", user.String(), action)
+	return nil
+}
+
+func readActiveFile(filename string) (map[string]bool, error) {
+	active := make(map[string]bool)
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return active, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return active, err
+	}
+	if len(records) == 0 {
+		return active, nil
+	}
+
+	columns := []int{}
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "email", "username":
+			columns = append(columns, i)
+		}
+	}
+	if len(columns) == 0 {
+		return active, fmt.Errorf("no 'email' or 'username' column in header row: %v", strings.Join(records[0], ","))
+	}
+
+	for _, record := range records[1:] {
+		for _, col := range columns {
+			if value := strings.ToLower(strings.TrimSpace(record[col])); value != "" {
+				active[value] = true
+			}
+		}
+	}
+
+	return active, nil
+}
+
+func readExcludeFile(filename string) (map[string]bool, error) {
+	excluded := make(map[string]bool)
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return excluded, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line != "" && !strings.HasPrefix(line, "#") {
+			excluded[line] = true
+		}
+	}
+
+	return excluded, scanner.Err()
+}
@@ -0,0 +1,84 @@
+package iam
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Client sends requests to the IAM (Keycloak) admin API of the tenant, for the calls that Cx1ClientGo does not cover
+type Client struct {
+	httpClient *http.Client
+	iamUrl     string
+	tenant     string
+}
+
+// NewClient re-uses the connection flags registered by Cx1ClientGo.NewClient, so it must be called after NewClient
+func NewClient(client *http.Client) (*Client, error) {
+	flagValue := func(name string) string {
+		if f := flag.Lookup(name); f != nil {
+			return f.Value.String()
+		}
+		return ""
+	}
+
+	iamUrl := flagValue("iam")
+	tenant := flagValue("tenant")
+	apiKey := flagValue("apikey")
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+	tokenUrl := fmt.Sprintf("%v/auth/realms/%v/protocol/openid-connect/token", iamUrl, tenant)
+
+	var tokenSource oauth2.TokenSource
+	if apiKey != "" {
+		conf := &oauth2.Config{
+			ClientID: "ast-app",
+			Endpoint: oauth2.Endpoint{TokenURL: tokenUrl},
+		}
+		tokenSource = conf.TokenSource(ctx, &oauth2.Token{RefreshToken: apiKey, Expiry: time.Now().UTC()})
+	} else {
+		conf := &clientcredentials.Config{
+			ClientID:     flagValue("client"),
+			ClientSecret: flagValue("secret"),
+			TokenURL:     tokenUrl,
+		}
+		tokenSource = conf.TokenSource(ctx)
+	}
+
+	if _, err := tokenSource.Token(); err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		httpClient: oauth2.NewClient(ctx, tokenSource),
+		iamUrl:     iamUrl,
+		tenant:     tenant,
+	}, nil
+}
+
+// SendRequest calls the admin API of the tenant's realm, api is the path after /auth/admin/realms/<tenant>
+func (c Client) SendRequest(method, api string, body io.Reader) ([]byte, error) {
+	request, err := http.NewRequest(method, fmt.Sprintf("%v/auth/admin/realms/%v%v", c.iamUrl, c.tenant, api), body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	data, _ := io.ReadAll(response.Body)
+	if response.StatusCode >= 400 {
+		return data, fmt.Errorf("HTTP %v: %v", response.Status, string(data))
+	}
+	return data, nil
+}
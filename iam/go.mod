@@ -0,0 +1,14 @@
+module github.com/cxpsemea/cx1_go_scripts/iam
+
+go 1.21
+
+require (
+	golang.org/x/oauth2 v0.13.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	google.golang.org/appengine v1.6.8 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+)
@@ -0,0 +1,40 @@
+package iam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// The Cx1ClientGo AuthenticationProviderMapper only carries the config keys used by the default mappers.
+// Advanced mappers (eg: attribute-to-group, claim-to-role) have other config keys, so they are read and created with the IAM admin API directly.
+type IdPMapper struct {
+	ID     string            `json:"id,omitempty"`
+	Name   string            `json:"name"`
+	Alias  string            `json:"identityProviderAlias"`
+	Mapper string            `json:"identityProviderMapper"`
+	Config map[string]string `json:"config"`
+}
+
+func (c Client) GetMappers(alias string) ([]IdPMapper, error) {
+	var mappers []IdPMapper
+	data, err := c.SendRequest(http.MethodGet, fmt.Sprintf("/identity-provider/instances/%v/mappers", url.PathEscape(alias)), nil)
+	if err != nil {
+		return mappers, err
+	}
+
+	err = json.Unmarshal(data, &mappers)
+	return mappers, err
+}
+
+func (c Client) AddMapper(mapper IdPMapper) error {
+	jsonBody, _ := json.Marshal(mapper)
+	_, err := c.SendRequest(http.MethodPost, fmt.Sprintf("/identity-provider/instances/%v/mappers", url.PathEscape(mapper.Alias)), bytes.NewReader(jsonBody))
+	return err
+}
+
+func (m IdPMapper) String() string {
+	return fmt.Sprintf("%v.%v mapper (%v)", m.Alias, m.Name, m.Mapper)
+}
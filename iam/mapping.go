@@ -0,0 +1,80 @@
+package iam
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ClaimMapping links one IdP-provided claim value (eg: AD-AppSec-Team-Payments) to a Cx1 group path and/or role
+type ClaimMapping struct {
+	Claim string `yaml:"claim"`
+	Group string `yaml:"group"`
+	Role  string `yaml:"role"`
+}
+
+func (m ClaimMapping) String() string {
+	targets := []string{}
+	if m.Group != "" {
+		targets = append(targets, fmt.Sprintf("group %v", m.Group))
+	}
+	if m.Role != "" {
+		targets = append(targets, fmt.Sprintf("role %v", m.Role))
+	}
+	return fmt.Sprintf("%v -> %v", m.Claim, strings.Join(targets, " + "))
+}
+
+// ReadMappingFile reads a YAML list of claim/group/role entries, or a CSV file with claim,group,role columns
+func ReadMappingFile(filename string) ([]ClaimMapping, error) {
+	var mappings []ClaimMapping
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return mappings, err
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		if err = yaml.Unmarshal(data, &mappings); err != nil {
+			return mappings, err
+		}
+	default:
+		reader := csv.NewReader(strings.NewReader(string(data)))
+		reader.Comment = '#'
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return mappings, err
+		}
+
+		for i, record := range records {
+			if i == 0 && strings.EqualFold(record[0], "claim") {
+				continue
+			}
+			if len(record) < 2 {
+				return mappings, fmt.Errorf("line %d: expected claim,group,role but got: %v", i+1, strings.Join(record, ","))
+			}
+			mapping := ClaimMapping{Claim: record[0], Group: record[1]}
+			if len(record) > 2 {
+				mapping.Role = record[2]
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+
+	for i := range mappings {
+		mappings[i].Claim = strings.TrimSpace(mappings[i].Claim)
+		mappings[i].Group = strings.TrimSpace(mappings[i].Group)
+		mappings[i].Role = strings.TrimSpace(mappings[i].Role)
+		if mappings[i].Claim == "" || (mappings[i].Group == "" && mappings[i].Role == "") {
+			return mappings, fmt.Errorf("entry %d: a claim value and at least one of group or role are required", i+1)
+		}
+	}
+
+	return mappings, nil
+}
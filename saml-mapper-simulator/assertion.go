@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type SAMLAttribute struct {
+	Name         string
+	FriendlyName string
+	Values       []string
+}
+
+// SAMLAssertion holds the parts of an assertion that the IdP mappers read
+type SAMLAssertion struct {
+	NameID     string
+	Attributes []SAMLAttribute
+}
+
+// ReadAssertionFile accepts either a bare <Assertion> or a full <Response> containing one; namespace prefixes are ignored
+func ReadAssertionFile(filename string) (SAMLAssertion, error) {
+	var assertion SAMLAssertion
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return assertion, err
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	var attribute *SAMLAttribute
+	path := []string{}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return assertion, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			switch t.Name.Local {
+			case "Attribute":
+				attribute = &SAMLAttribute{}
+				for _, a := range t.Attr {
+					switch a.Name.Local {
+					case "Name":
+						attribute.Name = a.Value
+					case "FriendlyName":
+						attribute.FriendlyName = a.Value
+					}
+				}
+			case "NameID":
+				if len(path) > 1 && path[len(path)-2] == "Subject" {
+					var value string
+					if err := decoder.DecodeElement(&value, &t); err != nil {
+						return assertion, err
+					}
+					assertion.NameID = strings.TrimSpace(value)
+					path = path[:len(path)-1]
+				}
+			case "AttributeValue":
+				if attribute != nil {
+					var value string
+					if err := decoder.DecodeElement(&value, &t); err != nil {
+						return assertion, err
+					}
+					attribute.Values = append(attribute.Values, strings.TrimSpace(value))
+					path = path[:len(path)-1]
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "Attribute" && attribute != nil {
+				assertion.Attributes = append(assertion.Attributes, *attribute)
+				attribute = nil
+			}
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+	}
+
+	if assertion.NameID == "" && len(assertion.Attributes) == 0 {
+		return assertion, fmt.Errorf("no NameID or attributes found in %v", filename)
+	}
+
+	return assertion, nil
+}
+
+// GetValues returns the values of the attribute matching either the Name or the FriendlyName, as Keycloak does
+func (a SAMLAssertion) GetValues(name string) []string {
+	values := []string{}
+	if name == "" {
+		return values
+	}
+	for _, attr := range a.Attributes {
+		if attr.Name == name || attr.FriendlyName == name {
+			values = append(values, attr.Values...)
+		}
+	}
+	return values
+}
+
+func (a SAMLAttribute) String() string {
+	if a.FriendlyName != "" && a.FriendlyName != a.Name {
+		return fmt.Sprintf("%v (%v) = %v", a.Name, a.FriendlyName, strings.Join(a.Values, ", "))
+	}
+	return fmt.Sprintf("%v = %v", a.Name, strings.Join(a.Values, ", "))
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cxpsemea/cx1_go_scripts/iam"
+)
+
+// MappedValue records a value set on the brokered user, and the mapper which produced it
+type MappedValue struct {
+	Value  string
+	Source string
+}
+
+type SimulatedUser struct {
+	Username   MappedValue
+	Email      MappedValue
+	FirstName  MappedValue
+	LastName   MappedValue
+	Attributes map[string]MappedValue
+	Groups     []MappedValue
+	Roles      []MappedValue
+	Unmatched  []string
+	Unknown    []string
+}
+
+var templateRE = regexp.MustCompile(`\$\{([^}|]+?)\s*(?:\|\s*([a-zA-Z]+)\s*)?\}`)
+
+// Simulate applies the mappers in order, the same way Keycloak does on a first broker login.
+// Without a username mapper, Keycloak uses the NameID as the username.
+func Simulate(alias string, mappers []iam.IdPMapper, assertion SAMLAssertion) SimulatedUser {
+	user := SimulatedUser{
+		Username:   MappedValue{assertion.NameID, "NameID (no username mapper)"},
+		Attributes: make(map[string]MappedValue),
+	}
+
+	for _, m := range mappers {
+		source := m.String()
+
+		switch m.Mapper {
+		case "saml-user-attribute-idp-mapper":
+			name := m.Config["attribute.name"]
+			if name == "" {
+				name = m.Config["attribute.friendly.name"]
+			}
+			values := assertion.GetValues(name)
+			if len(values) == 0 {
+				user.Unmatched = append(user.Unmatched, fmt.Sprintf("%v: attribute %v not present", source, name))
+				continue
+			}
+			value := MappedValue{values[0], source}
+			switch m.Config["user.attribute"] {
+			case "email":
+				user.Email = value
+			case "firstName":
+				user.FirstName = value
+			case "lastName":
+				user.LastName = value
+			case "username":
+				user.Username = value
+			default:
+				user.Attributes[m.Config["user.attribute"]] = value
+			}
+		case "saml-username-idp-mapper":
+			if target := m.Config["target"]; target != "" && target != "LOCAL" {
+				user.Unmatched = append(user.Unmatched, fmt.Sprintf("%v: target is %v, not the local username", source, target))
+				continue
+			}
+			user.Username = MappedValue{expandTemplate(m.Config["template"], alias, assertion), source}
+		case "custom-roles-saml-idp-mapper", "saml-role-idp-mapper":
+			name := m.Config["attribute.name"]
+			if name == "" {
+				name = m.Config["attribute.friendly.name"]
+			}
+			if contains(assertion.GetValues(name), m.Config["attribute.value"], false) {
+				user.Roles = append(user.Roles, MappedValue{m.Config["attribute.role"], source})
+			} else {
+				user.Unmatched = append(user.Unmatched, fmt.Sprintf("%v: %v does not contain %v", source, name, m.Config["attribute.value"]))
+			}
+		case "custom-group-saml-idp-mapper":
+			values := assertion.GetValues(m.Config["attribute.name"])
+			if len(values) == 0 {
+				user.Unmatched = append(user.Unmatched, fmt.Sprintf("%v: attribute %v not present", source, m.Config["attribute.name"]))
+			}
+			for _, v := range values {
+				user.Groups = append(user.Groups, MappedValue{v, source})
+			}
+		case "saml-advanced-group-idp-mapper", "saml-advanced-role-idp-mapper":
+			matched, reason := matchAdvanced(m, assertion)
+			if !matched {
+				user.Unmatched = append(user.Unmatched, fmt.Sprintf("%v: %v", source, reason))
+			} else if m.Mapper == "saml-advanced-group-idp-mapper" {
+				user.Groups = append(user.Groups, MappedValue{m.Config["group"], source})
+			} else {
+				user.Roles = append(user.Roles, MappedValue{m.Config["role"], source})
+			}
+		case "oidc-hardcoded-role-idp-mapper":
+			user.Roles = append(user.Roles, MappedValue{m.Config["role"], source})
+		case "oidc-hardcoded-group-idp-mapper":
+			user.Groups = append(user.Groups, MappedValue{m.Config["group"], source})
+		case "hardcoded-attribute-idp-mapper":
+			user.Attributes[m.Config["attribute"]] = MappedValue{m.Config["attribute.value"], source}
+		default:
+			user.Unknown = append(user.Unknown, source)
+		}
+	}
+
+	// Keycloak stores usernames in lower case
+	user.Username.Value = strings.ToLower(user.Username.Value)
+	return user
+}
+
+func expandTemplate(template, alias string, assertion SAMLAssertion) string {
+	return templateRE.ReplaceAllStringFunc(template, func(match string) string {
+		parts := templateRE.FindStringSubmatch(match)
+		variable := strings.TrimSpace(parts[1])
+
+		var value string
+		switch {
+		case variable == "ALIAS":
+			value = alias
+		case variable == "NAMEID":
+			value = assertion.NameID
+		case variable == "UUID":
+			value = "<random-uuid>"
+		case strings.HasPrefix(variable, "ATTRIBUTE."):
+			if values := assertion.GetValues(strings.TrimPrefix(variable, "ATTRIBUTE.")); len(values) > 0 {
+				value = values[0]
+			}
+		}
+
+		switch strings.ToLower(parts[2]) {
+		case "lowercase":
+			value = strings.ToLower(value)
+		case "uppercase":
+			value = strings.ToUpper(value)
+		case "localpart":
+			if i := strings.Index(value, "@"); i >= 0 {
+				value = value[:i]
+			}
+		}
+		return value
+	})
+}
+
+// matchAdvanced requires every key/value pair in the mapper's attributes config to be present in the assertion
+func matchAdvanced(m iam.IdPMapper, assertion SAMLAssertion) (bool, string) {
+	var attributes []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(m.Config["attributes"]), &attributes); err != nil {
+		return false, fmt.Sprintf("unable to parse attributes config '%v': %s", m.Config["attributes"], err)
+	}
+
+	regex := strings.EqualFold(m.Config["are.attribute.values.regex"], "true")
+	for _, a := range attributes {
+		if !contains(assertion.GetValues(a.Key), a.Value, regex) {
+			return false, fmt.Sprintf("%v does not contain %v", a.Key, a.Value)
+		}
+	}
+	return true, ""
+}
+
+func contains(values []string, expected string, regex bool) bool {
+	var re *regexp.Regexp
+	if regex {
+		var err error
+		if re, err = regexp.Compile("^(?:" + expected + ")$"); err != nil {
+			return false
+		}
+	}
+
+	for _, v := range values {
+		if (re != nil && re.MatchString(v)) || (re == nil && v == expected) {
+			return true
+		}
+	}
+	return false
+}
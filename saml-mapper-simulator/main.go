@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/cxpsemea/Cx1ClientGo"
+	"github.com/cxpsemea/cx1_go_scripts/iam"
+	"github.com/sirupsen/logrus"
+	easy "github.com/t-tomalak/logrus-easy-formatter"
+)
+
+func main() {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	myformatter := &easy.Formatter{}
+	myformatter.TimestampFormat = "2006-01-02 15:04:05.000"
+	myformatter.LogFormat = "[%lvl%][%time%] %msg%\n"
+	logger.SetFormatter(myformatter)
+	logger.SetOutput(os.Stdout)
+
+	logger.Info("Starting")
+	logger.Info("The purpose of this tool is to show which username, email, names, groups and roles a user would receive from a SAML assertion, and which IdP mapper produced each value.")
+
+	providerName := flag.String("provider-alias", "", "Alias (display name) of the SAML IdP in CheckmarxOne")
+	mappersFile := flag.String("mappers-file", "", "Optional: file containing an exported IdP mappers json (array of mappers, or a realm export with identityProviderMappers) - works offline")
+	assertionFile := flag.String("assertion", "", "File containing a sample SAML assertion or response XML")
+
+	httpClient := &http.Client{}
+	cx1client, err := Cx1ClientGo.NewClient(httpClient, logger)
+	if err != nil {
+		if *mappersFile != "" {
+			logger.Infof("A mappers-file was provided: working in offline mode")
+		} else {
+			logger.Fatalf("Error creating client: %s", err)
+		}
+	} else {
+		logger.Infof("Connected with %v", cx1client.String())
+	}
+
+	if *assertionFile == "" {
+		logger.Fatalf("The assertion parameter must be set")
+	}
+
+	var mappers []iam.IdPMapper
+
+	if *mappersFile != "" {
+		mappers, err = readMappersFile(*mappersFile, *providerName)
+		if err != nil {
+			logger.Fatalf("Failed to read mappers file %v: %s", *mappersFile, err)
+		}
+		logger.Infof("%d mappers read from file %v", len(mappers), *mappersFile)
+	} else {
+		if *providerName == "" {
+			logger.Fatalf("The provider-alias parameter must be set when not using a mappers-file")
+		}
+		idp, err := cx1client.GetAuthenticationProviderByAlias(*providerName)
+		if err != nil {
+			logger.Fatalf("Unable to get idp: %s", err)
+		}
+		logger.Infof("Found IDP: %v", idp.String())
+
+		iamclient, err := iam.NewClient(httpClient)
+		if err != nil {
+			logger.Fatalf("Error creating IAM client: %s", err)
+		}
+		mappers, err = iamclient.GetMappers(idp.Alias)
+		if err != nil {
+			logger.Fatalf("Failed to get mappers for idp %v: %s", idp.String(), err)
+		}
+		logger.Infof("Got %d mappers for idp %v", len(mappers), idp.String())
+	}
+
+	assertion, err := ReadAssertionFile(*assertionFile)
+	if err != nil {
+		logger.Fatalf("Failed to read assertion file %v: %s", *assertionFile, err)
+	}
+
+	logger.Infof("Assertion NameID: %v", assertion.NameID)
+	for _, a := range assertion.Attributes {
+		logger.Infof("Assertion attribute: %v", a.String())
+	}
+
+	user := Simulate(*providerName, mappers, assertion)
+
+	logger.Infof("The user would receive:")
+	logger.Infof(" - Username: %v", user.Username.String())
+	logger.Infof(" - Email: %v", user.Email.String())
+	logger.Infof(" - First name: %v", user.FirstName.String())
+	logger.Infof(" - Last name: %v", user.LastName.String())
+	for name, value := range user.Attributes {
+		logger.Infof(" - Attribute %v: %v", name, value.String())
+	}
+
+	if len(user.Groups) == 0 {
+		logger.Infof(" - Groups: none")
+	}
+	for _, g := range user.Groups {
+		logger.Infof(" - Group: %v", g.String())
+	}
+
+	if len(user.Roles) == 0 {
+		logger.Infof(" - Roles: none")
+	}
+	for _, r := range user.Roles {
+		logger.Infof(" - Role: %v", r.String())
+	}
+
+	for _, u := range user.Unmatched {
+		logger.Infof("Mapper did not apply: %v", u)
+	}
+	for _, u := range user.Unknown {
+		logger.Warnf("Mapper type is not supported by this simulator and was not evaluated: %v", u)
+	}
+
+	logger.Infof("Done")
+}
+
+func readMappersFile(filename, alias string) ([]iam.IdPMapper, error) {
+	var mappers []iam.IdPMapper
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return mappers, err
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		err = json.Unmarshal(data, &mappers)
+	} else {
+		var export struct {
+			IdentityProviderMappers []iam.IdPMapper `json:"identityProviderMappers"`
+		}
+		if err = json.Unmarshal(data, &export); err == nil {
+			mappers = export.IdentityProviderMappers
+		}
+	}
+
+	if alias == "" {
+		return mappers, err
+	}
+
+	filtered := []iam.IdPMapper{}
+	for _, m := range mappers {
+		if m.Alias == alias {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered, err
+}
+
+func (v MappedValue) String() string {
+	if v.Value == "" {
+		return "(not set)"
+	}
+	return v.Value + " <- " + v.Source
+}